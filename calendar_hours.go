@@ -0,0 +1,330 @@
+package hourstable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CalendarDateFormat of the override dates in JSON and YAML
+const CalendarDateFormat = "2006-01-02"
+
+// DateOverride replaces the hours of every day in the [From, To] dates range.
+// Only the date part of From and To is used.
+type DateOverride struct {
+	From  time.Time
+	To    time.Time
+	Hours DayHours
+}
+
+// Contains returns true if the date of t (in the location of t) is in the range
+func (o DateOverride) Contains(t time.Time) bool {
+	key := dateKey(t)
+	return dateKey(o.From) <= key && key <= dateKey(o.To)
+}
+
+// CalendarHours is the weekly hours table with the dated exceptions.
+// The overrides added later have priority over the previous ones,
+// then the holidays of the Holidays calendar use the HolidayHours row.
+type CalendarHours struct {
+	Hours     Hours
+	Overrides []DateOverride
+
+	Holidays     HolidayCalendar
+	HolidayHours DayHours
+}
+
+//easyjson:json
+type calendarJSON struct {
+	Hours     *timetableJSON     `json:"hours,omitempty" yaml:"hours,omitempty"`
+	Overrides []dateOverrideJSON `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+
+	HolidayHours *string       `json:"holiday_hours,omitempty" yaml:"holiday_hours,omitempty"`
+	Holidays     *HolidayRules `json:"holidays,omitempty" yaml:"holidays,omitempty"`
+}
+
+type dateOverrideJSON struct {
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to,omitempty" yaml:"to,omitempty"`
+	Hours string `json:"hours,omitempty" yaml:"hours,omitempty"`
+}
+
+// CalendarHoursByJSON decodes JSON format of the calendar
+func CalendarHoursByJSON(data []byte) (c CalendarHours, err error) {
+	var calendar calendarJSON
+	if err = json.Unmarshal(data, &calendar); err != nil {
+		return c, err
+	}
+	err = c.fromJSON(&calendar)
+	return c, err
+}
+
+// SetDate replaces the hours of the date
+func (c *CalendarHours) SetDate(date time.Time, hours DayHours) {
+	c.SetDates(date, date, hours)
+}
+
+// SetDates replaces the hours of every date in the [from, to] range
+func (c *CalendarHours) SetDates(from, to time.Time, hours DayHours) {
+	c.Overrides = append(c.Overrides, DateOverride{
+		From:  dateOf(from),
+		To:    dateOf(to),
+		Hours: hours & AllDayHours,
+	})
+}
+
+// Override returns the hours of the date of t if the date has override
+func (c CalendarHours) Override(t time.Time) (DayHours, bool) {
+	for i := len(c.Overrides) - 1; i >= 0; i-- {
+		if c.Overrides[i].Contains(t) {
+			return c.Overrides[i].Hours, true
+		}
+	}
+	return NoDayHours, false
+}
+
+// Holiday returns the name of the holiday of the date of t if the calendar has it
+func (c CalendarHours) Holiday(t time.Time) (string, bool) {
+	if c.Holidays == nil {
+		return "", false
+	}
+	return c.Holidays.IsHoliday(t)
+}
+
+// TestTime hour
+func (c CalendarHours) TestTime(t time.Time) bool {
+	if hours, ok := c.Override(t); ok {
+		return hours.TestHour(byte(t.Hour()))
+	}
+	if _, ok := c.Holiday(t); ok {
+		return c.HolidayHours.TestHour(byte(t.Hour()))
+	}
+	return c.Hours.TestTime(t)
+}
+
+// NextActive returns t if the calendar is active at t, otherwise the start
+// of the next active hour, see Hours.NextActive
+func (c CalendarHours) NextActive(t time.Time) (time.Time, error) {
+	return nextState(c.TestTime, t, true, c.lookupSteps(t, true), ErrNeverActive)
+}
+
+// NextInactive returns t if the calendar is inactive at t, otherwise the end
+// of the current active period, see Hours.NextInactive
+func (c CalendarHours) NextInactive(t time.Time) (time.Time, error) {
+	return nextState(c.TestTime, t, false, c.lookupSteps(t, true), ErrAlwaysActive)
+}
+
+// PrevActive returns t if the calendar is active at t, otherwise the end
+// of the previous active period, see Hours.PrevActive
+func (c CalendarHours) PrevActive(t time.Time) (time.Time, error) {
+	return prevState(c.TestTime, t, true, c.lookupSteps(t, false), ErrNeverActive)
+}
+
+// PrevInactive returns t if the calendar is inactive at t, otherwise the start
+// of the current active period, see Hours.PrevInactive
+func (c CalendarHours) PrevInactive(t time.Time) (time.Time, error) {
+	return prevState(c.TestTime, t, false, c.lookupSteps(t, false), ErrAlwaysActive)
+}
+
+// Equal comarison of two calendars, the holiday calendars are not compared
+func (c CalendarHours) Equal(c2 CalendarHours) bool {
+	if !c.Hours.Equal(c2.Hours) || len(c.Overrides) != len(c2.Overrides) ||
+		c.HolidayHours&AllDayHours != c2.HolidayHours&AllDayHours {
+		return false
+	}
+	for i, o := range c.Overrides {
+		o2 := c2.Overrides[i]
+		if dateKey(o.From) != dateKey(o2.From) || dateKey(o.To) != dateKey(o2.To) || o.Hours != o2.Hours {
+			return false
+		}
+	}
+	return true
+}
+
+// String implementation of fmt.Stringer
+func (c CalendarHours) String() string {
+	data, _ := c.MarshalJSON()
+	return string(data)
+}
+
+// Value implementation of valuer for database/sql
+func (c CalendarHours) Value() (driver.Value, error) {
+	return c.MarshalJSON()
+}
+
+// Scan - Implement the database/sql scanner interface
+func (c *CalendarHours) Scan(value any) (err error) {
+	if value == nil {
+		*c = CalendarHours{}
+		return nil
+	}
+
+	var newCalendar CalendarHours
+	switch v := value.(type) {
+	case []byte:
+		if newCalendar, err = CalendarHoursByJSON(v); err == nil {
+			*c = newCalendar
+		}
+	case string:
+		if newCalendar, err = CalendarHoursByJSON([]byte(v)); err == nil {
+			*c = newCalendar
+		}
+	default:
+		err = fmt.Errorf("[hours_calendar] unsupported decode type %T", value)
+	}
+	return
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (c CalendarHours) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.toJSON())
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (c *CalendarHours) UnmarshalJSON(data []byte) error {
+	newCalendar, err := CalendarHoursByJSON(data)
+	if err != nil {
+		return err
+	}
+	*c = newCalendar
+	return nil
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (c CalendarHours) MarshalYAML() (any, error) {
+	return c.toJSON(), nil
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (c *CalendarHours) UnmarshalYAML(node *yaml.Node) error {
+	var (
+		calendar    calendarJSON
+		newCalendar CalendarHours
+	)
+	if err := node.Decode(&calendar); err != nil {
+		return err
+	}
+	if err := newCalendar.fromJSON(&calendar); err != nil {
+		return err
+	}
+	*c = newCalendar
+	return nil
+}
+
+// Clone returns a copy of CalendarHours, the holiday calendar is shared
+func (c CalendarHours) Clone() CalendarHours {
+	newCalendar := CalendarHours{
+		Hours:        c.Hours.Clone(),
+		Holidays:     c.Holidays,
+		HolidayHours: c.HolidayHours,
+	}
+	if c.Overrides != nil {
+		newCalendar.Overrides = make([]DateOverride, len(c.Overrides))
+		copy(newCalendar.Overrides, c.Overrides)
+	}
+	return newCalendar
+}
+
+func (c *CalendarHours) toJSON() *calendarJSON {
+	calendar := &calendarJSON{}
+	if len(c.Hours) > 0 {
+		calendar.Hours = &timetableJSON{}
+		calendar.Hours.FromHours(c.Hours)
+	}
+	for _, o := range c.Overrides {
+		override := dateOverrideJSON{
+			From:  o.From.Format(CalendarDateFormat),
+			Hours: o.Hours.String(),
+		}
+		if dateKey(o.To) != dateKey(o.From) {
+			override.To = o.To.Format(CalendarDateFormat)
+		}
+		calendar.Overrides = append(calendar.Overrides, override)
+	}
+	if c.Holidays != nil {
+		holidayHours := c.HolidayHours.String()
+		calendar.HolidayHours = &holidayHours
+		// Only the rule based calendars could be stored with the schedule
+		calendar.Holidays, _ = c.Holidays.(*HolidayRules)
+	}
+	return calendar
+}
+
+func (c *CalendarHours) fromJSON(calendar *calendarJSON) (err error) {
+	c.Hours, c.Overrides, c.Holidays, c.HolidayHours = nil, nil, nil, NoDayHours
+	if calendar.Hours != nil {
+		c.Hours = calendar.Hours.ToHours()
+	}
+	for _, o := range calendar.Overrides {
+		var override DateOverride
+		if override.From, err = time.Parse(CalendarDateFormat, o.From); err != nil {
+			return fmt.Errorf("[hours_calendar] invalid date %q: %w", o.From, err)
+		}
+		override.To = override.From
+		if o.To != "" {
+			if override.To, err = time.Parse(CalendarDateFormat, o.To); err != nil {
+				return fmt.Errorf("[hours_calendar] invalid date %q: %w", o.To, err)
+			}
+		}
+		if override.Hours, err = DayHoursByString(o.Hours); err != nil {
+			return err
+		}
+		c.Overrides = append(c.Overrides, override)
+	}
+	if calendar.HolidayHours != nil {
+		if c.HolidayHours, err = DayHoursByString(*calendar.HolidayHours); err != nil {
+			return err
+		}
+	}
+	if calendar.Holidays != nil {
+		c.Holidays = calendar.Holidays
+	}
+	return nil
+}
+
+// lookupSteps returns number of hours to walk from t to cover all overrides and a week after them
+func (c CalendarHours) lookupSteps(t time.Time, forward bool) int {
+	steps := lookupSteps
+	for _, o := range c.Overrides {
+		var distance time.Duration
+		if forward {
+			distance = dateIn(o.To, 1, t.Location()).Sub(t)
+		} else {
+			distance = t.Sub(dateIn(o.From, 0, t.Location()))
+		}
+		if n := int(distance/time.Hour) + lookupSteps; n > steps {
+			steps = n
+		}
+	}
+	return steps
+}
+
+// dateKey returns comparable number of the date of t
+func dateKey(t time.Time) int {
+	year, month, day := t.Date()
+	return year*10000 + int(month)*100 + day
+}
+
+// dateOf returns the date of t as midnight of UTC
+func dateOf(t time.Time) time.Time {
+	return dateIn(t, 0, time.UTC)
+}
+
+// dateIn returns the midnight of the date of t shifted by days in the loc
+func dateIn(t time.Time, days int, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day+days, 0, 0, 0, 0, loc)
+}
+
+var (
+	_ json.Marshaler   = CalendarHours{}
+	_ json.Unmarshaler = (*CalendarHours)(nil)
+	_ yaml.Marshaler   = CalendarHours{}
+	_ yaml.Unmarshaler = (*CalendarHours)(nil)
+	_ driver.Valuer    = CalendarHours{}
+	_ sql.Scanner      = (*CalendarHours)(nil)
+)
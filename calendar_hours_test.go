@@ -0,0 +1,146 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestCalendarHours_TestTime(t *testing.T) {
+	var calendar CalendarHours
+	calendar.Hours = MustHoursByRanges("Mon-Fri 9-17")
+	calendar.SetDate(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), NoDayHours)
+	calendar.SetDates(time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 27, 0, 0, 0, 0, time.UTC), RangeDayHours(10, 14))
+	calendar.SetDate(time.Date(2026, 12, 27, 0, 0, 0, 0, time.UTC), RangeDayHours(12, 13))
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected bool
+	}{
+		{name: "weekly active", t: time.Date(2026, 12, 24, 10, 0, 0, 0, time.UTC), expected: true},
+		{name: "weekly inactive", t: time.Date(2026, 12, 24, 8, 0, 0, 0, time.UTC), expected: false},
+		{name: "holiday", t: time.Date(2026, 12, 25, 10, 0, 0, 0, time.UTC), expected: false},
+		{name: "opened saturday", t: time.Date(2026, 12, 26, 11, 0, 0, 0, time.UTC), expected: true},
+		{name: "closed hour of opened saturday", t: time.Date(2026, 12, 26, 15, 0, 0, 0, time.UTC), expected: false},
+		{name: "later override wins", t: time.Date(2026, 12, 27, 11, 0, 0, 0, time.UTC), expected: false},
+		{name: "later override active", t: time.Date(2026, 12, 27, 12, 30, 0, 0, time.UTC), expected: true},
+		{name: "date of local time", t: time.Date(2026, 12, 25, 10, 0, 0, 0, time.FixedZone("UTC-12", -12*60*60)), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if res := calendar.TestTime(tt.t); res != tt.expected {
+				t.Errorf("TestTime(%v) = %v, expected %v", tt.t, res, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalendarHours_Lookup(t *testing.T) {
+	var calendar CalendarHours
+	calendar.Hours = MustHoursByRanges("Mon-Fri 9-17")
+	calendar.SetDates(time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), NoDayHours)
+
+	next, err := calendar.NextActive(time.Date(2026, 12, 23, 18, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NextActive() error = %v", err)
+	}
+	if expected := time.Date(2027, 1, 4, 9, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("NextActive() = %v, expected %v", next, expected)
+	}
+
+	prev, err := calendar.PrevActive(time.Date(2027, 1, 4, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("PrevActive() error = %v", err)
+	}
+	if expected := time.Date(2026, 12, 23, 17, 0, 0, 0, time.UTC); !prev.Equal(expected) {
+		t.Errorf("PrevActive() = %v, expected %v", prev, expected)
+	}
+
+	// The far override extends the lookup range
+	var closed CalendarHours
+	closed.Hours = make(Hours, 24)
+	closed.SetDate(time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC), RangeDayHours(10, 12))
+	next, err = closed.NextActive(time.Date(2026, 12, 23, 18, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NextActive() error = %v", err)
+	}
+	if expected := time.Date(2027, 6, 1, 10, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("NextActive() = %v, expected %v", next, expected)
+	}
+	if _, err = closed.NextActive(time.Date(2027, 6, 2, 0, 0, 0, 0, time.UTC)); err != ErrNeverActive {
+		t.Errorf("NextActive() error = %v, expected %v", err, ErrNeverActive)
+	}
+}
+
+func TestCalendarHours_Encode(t *testing.T) {
+	var calendar CalendarHours
+	calendar.Hours = MustHoursByRanges("Mon-Fri 9-17")
+	calendar.SetDate(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), NoDayHours)
+	calendar.SetDates(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC), RangeDayHours(10, 14))
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(calendar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded CalendarHours
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(calendar) {
+			t.Errorf("json decoded = %v, expected %v", decoded, calendar)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := yaml.Marshal(calendar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded CalendarHours
+		if err = yaml.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(calendar) {
+			t.Errorf("yaml decoded = %v, expected %v", decoded, calendar)
+		}
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		value, err := calendar.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded CalendarHours
+		if err = decoded.Scan(value); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(calendar) {
+			t.Errorf("sql decoded = %v, expected %v", decoded, calendar)
+		}
+	})
+
+	t.Run("all active", func(t *testing.T) {
+		var decoded CalendarHours
+		if err := json.Unmarshal([]byte(`{"overrides":[{"from":"2026-12-25"}]}`), &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Hours.IsAllActive() {
+			t.Errorf("decoded hours = %v, expected all active", decoded.Hours)
+		}
+		if decoded.TestTime(time.Date(2026, 12, 25, 10, 0, 0, 0, time.UTC)) {
+			t.Error("decoded override must be inactive")
+		}
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		var decoded CalendarHours
+		if err := json.Unmarshal([]byte(`{"overrides":[{"from":"25.12.2026"}]}`), &decoded); err == nil {
+			t.Error("expected error of invalid date")
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package hourstable
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrTooMuchDayHoursForDecode tells that hours more then for a day
+var ErrTooMuchDayHoursForDecode = errors.New("[hours] too much hours for decode, more then 24")
+
+// DayHours is a bitmask of active hours of one day, bit N is the hour N
+type DayHours uint32
+
+// Predefined day hours
+const (
+	NoDayHours  DayHours = 0
+	AllDayHours DayHours = 1<<24 - 1
+)
+
+// DayHoursByString decodes the row of the day in the same format
+// as the days of the JSON timetable: "*" - all active, "" - all inactive
+// or up to 24 symbols of '1' and '0'
+func DayHoursByString(s string) (DayHours, error) {
+	if s == AllActiveHoursString {
+		return AllDayHours, nil
+	}
+	if len(s) > 24 {
+		return 0, ErrTooMuchDayHoursForDecode
+	}
+	var d DayHours
+	for i, c := range s {
+		if c == '1' {
+			d |= 1 << i
+		}
+	}
+	return d, nil
+}
+
+// RangeDayHours returns the day hours with active [from, to) hours
+func RangeDayHours(from, to byte) DayHours {
+	if to > 24 {
+		to = 24
+	}
+	if from >= to {
+		return NoDayHours
+	}
+	return AllDayHours >> (24 - to) &^ (1<<from - 1)
+}
+
+// String returns the short form of the day row: "*", "" or 24 symbols of '1' and '0'
+func (d DayHours) String() string {
+	switch d & AllDayHours {
+	case AllDayHours:
+		return AllActiveHoursString
+	case NoDayHours:
+		return ""
+	}
+	var buff strings.Builder
+	for hour := 0; hour < 24; hour++ {
+		if d&(1<<hour) != 0 {
+			buff.WriteByte('1')
+		} else {
+			buff.WriteByte('0')
+		}
+	}
+	return buff.String()
+}
+
+// TestHour hour
+func (d DayHours) TestHour(hour byte) bool {
+	return hour < 24 && d&(1<<hour) != 0
+}
+
+// SetHour as active or no
+func (d *DayHours) SetHour(hour byte, active bool) {
+	if hour >= 24 {
+		return
+	}
+	if active {
+		*d |= 1 << hour
+	} else {
+		*d &= ^(1 << hour)
+	}
+}
+
+// Day returns active hours of the week day
+func (h Hours) Day(weekDay time.Weekday) (d DayHours) {
+	for hour := byte(0); hour < 24; hour++ {
+		if h.TestHour(weekDay, hour) {
+			d |= 1 << hour
+		}
+	}
+	return d
+}
+
+// SetDay replaces all hours of the week day
+func (h *Hours) SetDay(weekDay time.Weekday, d DayHours) {
+	if len(*h) < 1 && d&AllDayHours != AllDayHours {
+		// Keep other days of the all active table
+		*h = make(Hours, 24)
+		for i := range *h {
+			(*h)[i] = daysBitMask
+		}
+	}
+	for hour := byte(0); hour < 24; hour++ {
+		h.SetHour(weekDay, hour, d.TestHour(hour))
+	}
+}
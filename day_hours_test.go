@@ -0,0 +1,59 @@
+package hourstable
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayHours(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected DayHours
+		result   string
+	}{
+		{name: "all active", input: "*", expected: AllDayHours, result: "*"},
+		{name: "all inactive", input: "", expected: NoDayHours, result: ""},
+		{name: "full row of zeros", input: DisabledDayHoursString, expected: NoDayHours, result: ""},
+		{name: "full row of ones", input: ActiveDayHoursString, expected: AllDayHours, result: "*"},
+		{name: "short row", input: "0011", expected: RangeDayHours(2, 4), result: "001100000000000000000000"},
+		{name: "range", input: ActiveHoursRangeString(9, 17), expected: RangeDayHours(9, 17), result: "000000000111111110000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := DayHoursByString(tt.input)
+			if err != nil {
+				t.Fatalf("DayHoursByString() error = %v", err)
+			}
+			if d != tt.expected {
+				t.Errorf("DayHoursByString() = %s, expected %s", d, tt.expected)
+			}
+			if d.String() != tt.result {
+				t.Errorf("String() = %q, expected %q", d, tt.result)
+			}
+		})
+	}
+
+	if _, err := DayHoursByString(ActiveDayHoursString + "1"); err != ErrTooMuchDayHoursForDecode {
+		t.Errorf("DayHoursByString() error = %v, expected %v", err, ErrTooMuchDayHoursForDecode)
+	}
+}
+
+func TestHours_Day(t *testing.T) {
+	h := MustHoursByRanges("Mon-Fri 9-17")
+	if d := h.Day(time.Monday); d != RangeDayHours(9, 17) {
+		t.Errorf("Day(Monday) = %s, expected %s", d, RangeDayHours(9, 17))
+	}
+
+	h.SetDay(time.Monday, RangeDayHours(10, 12))
+	if !h.Equal(MustHoursByRanges("Mon 10-12; Tue-Fri 9-17")) {
+		t.Errorf("SetDay() = %s", h.RangesString())
+	}
+
+	var all Hours
+	all.SetDay(time.Sunday, NoDayHours)
+	if !all.Equal(MustHoursByRanges("Mon-Sat")) {
+		t.Errorf("SetDay() of all active = %s", all.RangesString())
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/geniusrabbit/hourstable/gormhours
+
+go 1.21
+
+require (
+	github.com/geniusrabbit/hourstable v0.0.0-20261017060307-bad43c304d9e
+	gorm.io/driver/sqlite v1.6.0
+	gorm.io/gorm v1.31.1
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/mattn/go-sqlite3 v1.14.22 // indirect
+	golang.org/x/text v0.20.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
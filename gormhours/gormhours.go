@@ -0,0 +1,260 @@
+// Package gormhours implements the storage of the hours tables for gorm.io/gorm.
+//
+// The package registers the "hours" serializer, so any hourstable.Hours or
+// hourstable.HoursObject field could be stored with the tag
+//
+//	Hours hourstable.Hours `gorm:"serializer:hours;type:bytea"`
+//
+// The storage format is selected by the type of the column: the binary types
+// (bytes, bytea, blob, binary) keep the compact binary format, the json types
+// (json, jsonb) keep the JSON object and the others keep the text format.
+// The fields without the type tag are stored as bytes like any other []byte.
+//
+// Hours, BinaryHours and HoursObject are the types with the same formats which
+// define the column type of the migration for Postgres, MySQL and SQLite.
+// Every format is decoded by all of the types and the serializer, so the storage
+// format could be changed without migration of the data.
+package gormhours
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/geniusrabbit/hourstable"
+)
+
+// SerializerName of the hours serializer in the gorm tag
+const SerializerName = "hours"
+
+// Format of the hours in the database column
+type Format int
+
+// Format list
+const (
+	FormatString Format = iota
+	FormatBytes
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatBytes:
+		return "bytes"
+	case FormatJSON:
+		return "json"
+	}
+	return "string"
+}
+
+// FormatOf the field by the data type of the column
+func FormatOf(field *schema.Field) Format {
+	dataType := strings.ToLower(string(field.DataType))
+	switch {
+	case dataType == string(schema.Bytes),
+		strings.Contains(dataType, "bytea"),
+		strings.Contains(dataType, "blob"),
+		strings.Contains(dataType, "binary"):
+		return FormatBytes
+	case strings.Contains(dataType, "json"):
+		return FormatJSON
+	}
+	return FormatString
+}
+
+// Serializer of the hours fields, implements schema.SerializerInterface
+type Serializer struct{}
+
+// Scan implements serializer interface
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	hours, err := decode(dbValue)
+	if err != nil {
+		return err
+	}
+	fieldValue := reflect.New(field.FieldType).Elem()
+	if dbValue != nil {
+		target := fieldValue
+		if target.Kind() == reflect.Ptr {
+			target.Set(reflect.New(target.Type().Elem()))
+			target = target.Elem()
+		}
+		if !reflect.TypeOf(hours).ConvertibleTo(target.Type()) {
+			return fmt.Errorf("[gormhours] unsupported field type %s", field.FieldType)
+		}
+		target.Set(reflect.ValueOf(hours).Convert(target.Type()))
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value implements serializer interface
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	value := reflect.ValueOf(fieldValue)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+	hoursType := reflect.TypeOf(hourstable.Hours(nil))
+	if !value.IsValid() || !value.Type().ConvertibleTo(hoursType) {
+		return nil, fmt.Errorf("[gormhours] unsupported field type %T", fieldValue)
+	}
+	return encode(value.Convert(hoursType).Interface().(hourstable.Hours), FormatOf(field))
+}
+
+// Hours is the hourstable.Hours stored in the text format
+type Hours hourstable.Hours
+
+// Value implementation of valuer for database/sql
+func (h Hours) Value() (driver.Value, error) {
+	return encode(hourstable.Hours(h), FormatString)
+}
+
+// Scan - Implement the database/sql scanner interface
+func (h *Hours) Scan(value any) error {
+	hours, err := decode(value)
+	if err == nil {
+		*h = Hours(hours)
+	}
+	return err
+}
+
+// GormDataType of the field
+func (Hours) GormDataType() string {
+	return FormatString.String()
+}
+
+// GormDBDataType of the column
+func (Hours) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return dbDataType(db, FormatString)
+}
+
+// BinaryHours is the hourstable.Hours stored in the compact binary format
+type BinaryHours hourstable.Hours
+
+// Value implementation of valuer for database/sql
+func (h BinaryHours) Value() (driver.Value, error) {
+	return encode(hourstable.Hours(h), FormatBytes)
+}
+
+// Scan - Implement the database/sql scanner interface
+func (h *BinaryHours) Scan(value any) error {
+	hours, err := decode(value)
+	if err == nil {
+		*h = BinaryHours(hours)
+	}
+	return err
+}
+
+// GormDataType of the field
+func (BinaryHours) GormDataType() string {
+	return FormatBytes.String()
+}
+
+// GormDBDataType of the column
+func (BinaryHours) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return dbDataType(db, FormatBytes)
+}
+
+// HoursObject is the hourstable.HoursObject stored as JSON object
+type HoursObject hourstable.HoursObject
+
+// Value implementation of valuer for database/sql
+func (h HoursObject) Value() (driver.Value, error) {
+	return encode(hourstable.Hours(h), FormatJSON)
+}
+
+// Scan - Implement the database/sql scanner interface
+func (h *HoursObject) Scan(value any) error {
+	hours, err := decode(value)
+	if err == nil {
+		*h = HoursObject(hours)
+	}
+	return err
+}
+
+// GormDataType of the field
+func (HoursObject) GormDataType() string {
+	return FormatJSON.String()
+}
+
+// GormDBDataType of the column
+func (HoursObject) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return dbDataType(db, FormatJSON)
+}
+
+func dbDataType(db *gorm.DB, format Format) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		switch format {
+		case FormatBytes:
+			return "bytea"
+		case FormatJSON:
+			return "jsonb"
+		}
+		return "varchar(168)"
+	case "mysql":
+		switch format {
+		case FormatBytes:
+			return "varbinary(21)"
+		case FormatJSON:
+			return "json"
+		}
+		return "varchar(168)"
+	case "sqlite":
+		if format == FormatBytes {
+			return "blob"
+		}
+		return "text"
+	}
+	return ""
+}
+
+func encode(hours hourstable.Hours, format Format) (driver.Value, error) {
+	switch format {
+	case FormatBytes:
+		return hours.MarshalBinary()
+	case FormatJSON:
+		data, err := hourstable.HoursObject(hours).MarshalJSON()
+		return string(data), err
+	}
+	return hours.String(), nil
+}
+
+func decode(value any) (hourstable.Hours, error) {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil, fmt.Errorf("[gormhours] unsupported decode type %T", value)
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return hourstable.HoursByJSON(trimmed)
+	}
+	var hours hourstable.BinaryHours
+	err := hours.Scan(data)
+	return hourstable.Hours(hours), err
+}
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+var (
+	_ schema.SerializerInterface   = Serializer{}
+	_ schema.GormDataTypeInterface = Hours(nil)
+	_ schema.GormDataTypeInterface = BinaryHours(nil)
+	_ schema.GormDataTypeInterface = HoursObject(nil)
+)
@@ -0,0 +1,201 @@
+package gormhours
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/geniusrabbit/hourstable"
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+type campaign struct {
+	ID          uint64
+	Text        hourstable.Hours       `gorm:"serializer:hours;type:text"`
+	Bytes       hourstable.Hours       `gorm:"serializer:hours;type:blob"`
+	JSON        hourstable.HoursObject `gorm:"serializer:hours;type:json"`
+	Optional    *hourstable.Hours      `gorm:"serializer:hours;type:varchar(168)"`
+	Hours       Hours
+	Binary      BinaryHours
+	HoursObject HoursObject
+}
+
+func openDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&campaign{}); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	return db
+}
+
+func TestSerializer(t *testing.T) {
+	db := openDB(t)
+
+	for _, tt := range hourstest.Tables() {
+		t.Run(tt.Name, func(t *testing.T) {
+			hours := hourstable.Hours(tt.Hours)
+			record := campaign{
+				Text:        hours,
+				Bytes:       hours,
+				JSON:        hourstable.HoursObject(hours),
+				Optional:    &hours,
+				Hours:       Hours(hours),
+				Binary:      BinaryHours(hours),
+				HoursObject: HoursObject(hours),
+			}
+			if err := db.Create(&record).Error; err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			var decoded campaign
+			if err := db.First(&decoded, record.ID).Error; err != nil {
+				t.Fatalf("First() error = %v", err)
+			}
+			if decoded.Optional == nil {
+				t.Fatal("Optional = nil, expected hours")
+			}
+			for _, h := range []hourstable.Hours{
+				decoded.Text,
+				decoded.Bytes,
+				hourstable.Hours(decoded.JSON),
+				*decoded.Optional,
+				hourstable.Hours(decoded.Hours),
+				hourstable.Hours(decoded.Binary),
+				hourstable.Hours(decoded.HoursObject),
+			} {
+				if !h.Equal(hours) {
+					t.Errorf("First() = %v, expected %v", h, hours)
+				}
+			}
+		})
+	}
+}
+
+func TestSerializer_Storage(t *testing.T) {
+	db := openDB(t)
+	business := hourstable.MustHoursByRanges("Mon-Fri 9-17")
+	record := campaign{Text: business, Bytes: business, JSON: hourstable.HoursObject(business)}
+	if err := db.Create(&record).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	var raw struct {
+		Text     string
+		Bytes    []byte
+		JSON     string
+		Optional *string
+	}
+	if err := db.Table("campaigns").Where("id = ?", record.ID).Take(&raw).Error; err != nil {
+		t.Fatal(err)
+	}
+	if raw.Text != business.String() {
+		t.Errorf("text = %s, expected %s", raw.Text, business.String())
+	}
+	if expected, _ := business.MarshalBinary(); string(raw.Bytes) != string(expected) {
+		t.Errorf("bytes = %x, expected %x", raw.Bytes, expected)
+	}
+	if expected, _ := hourstable.HoursObject(business).MarshalJSON(); raw.JSON != string(expected) {
+		t.Errorf("json = %s, expected %s", raw.JSON, expected)
+	}
+	if raw.Optional != nil {
+		t.Errorf("optional = %s, expected NULL", *raw.Optional)
+	}
+
+	// Any format is decoded by every field
+	if err := db.Table("campaigns").Where("id = ?", record.ID).Updates(map[string]any{
+		"text":         raw.JSON,
+		"bytes":        raw.Text,
+		"json":         raw.Bytes,
+		"hours":        raw.Bytes,
+		"binary":       raw.JSON,
+		"hours_object": raw.Text,
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	var decoded campaign
+	if err := db.First(&decoded, record.ID).Error; err != nil {
+		t.Fatalf("First() error = %v", err)
+	}
+	if decoded.Optional != nil {
+		t.Errorf("Optional = %v, expected nil", decoded.Optional)
+	}
+	for _, h := range []hourstable.Hours{
+		decoded.Text,
+		decoded.Bytes,
+		hourstable.Hours(decoded.JSON),
+		hourstable.Hours(decoded.Hours),
+		hourstable.Hours(decoded.Binary),
+		hourstable.Hours(decoded.HoursObject),
+	} {
+		if !h.Equal(business) {
+			t.Errorf("First() = %v, expected %v", h, business)
+		}
+	}
+
+	// NULL columns are the missing tables
+	if err := db.Table("campaigns").Where("id = ?", record.ID).Updates(map[string]any{
+		"hours": nil, "binary": nil, "hours_object": nil,
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	decoded = campaign{}
+	if err := db.First(&decoded, record.ID).Error; err != nil {
+		t.Fatalf("First() error = %v", err)
+	}
+	if decoded.Hours != nil || decoded.Binary != nil || decoded.HoursObject != nil {
+		t.Errorf("First() = %v, %v, %v, expected nil", decoded.Hours, decoded.Binary, decoded.HoursObject)
+	}
+}
+
+type dialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (d dialector) Name() string { return d.name }
+
+func TestGormDBDataType(t *testing.T) {
+	tests := []struct {
+		dialect string
+		hours   string
+		binary  string
+		object  string
+	}{
+		{dialect: "postgres", hours: "varchar(168)", binary: "bytea", object: "jsonb"},
+		{dialect: "mysql", hours: "varchar(168)", binary: "varbinary(21)", object: "json"},
+		{dialect: "sqlite", hours: "text", binary: "blob", object: "text"},
+		{dialect: "unknown", hours: "", binary: "", object: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			db := &gorm.DB{Config: &gorm.Config{Dialector: dialector{name: tt.dialect}}}
+			if tp := (Hours{}).GormDBDataType(db, nil); tp != tt.hours {
+				t.Errorf("Hours.GormDBDataType() = %q, expected %q", tp, tt.hours)
+			}
+			if tp := (BinaryHours{}).GormDBDataType(db, nil); tp != tt.binary {
+				t.Errorf("BinaryHours.GormDBDataType() = %q, expected %q", tp, tt.binary)
+			}
+			if tp := (HoursObject{}).GormDBDataType(db, nil); tp != tt.object {
+				t.Errorf("HoursObject.GormDBDataType() = %q, expected %q", tp, tt.object)
+			}
+		})
+	}
+
+	// The migration of SQLite uses the types of the dialect
+	db := openDB(t)
+	columns, err := db.Migrator().ColumnTypes(&campaign{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"hours": "text", "binary": "blob", "hours_object": "text", "bytes": "blob"}
+	for _, column := range columns {
+		if tp, ok := expected[column.Name()]; ok && column.DatabaseTypeName() != tp {
+			t.Errorf("column %s type = %s, expected %s", column.Name(), column.DatabaseTypeName(), tp)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HolidayDateFormat of the fixed holiday dates in the definition files
+const HolidayDateFormat = "01-02"
+
+// ErrInvalidHolidayRule tells that the holiday rule has no date definition
+var ErrInvalidHolidayRule = errors.New("[holidays] invalid holiday rule, one of date, nth weekday or easter is required")
+
+// HolidayCalendar provides the list of holidays for the schedule
+type HolidayCalendar interface {
+	// IsHoliday returns the name of the holiday if the date of t
+	// (in the location of t) is the holiday
+	IsHoliday(t time.Time) (name string, ok bool)
+}
+
+// HolidayRuleKind of the holiday date definition
+type HolidayRuleKind int
+
+// Holiday rule kinds
+const (
+	HolidayFixedDate HolidayRuleKind = iota
+	HolidayNthWeekday
+	HolidayEasterRelative
+)
+
+// HolidayRule describes the holiday date repeated every year
+type HolidayRule struct {
+	Name string
+	Kind HolidayRuleKind
+
+	// Month of the fixed date or the nth weekday
+	Month time.Month
+
+	// Day of the month of the fixed date
+	Day int
+
+	// Weekday and Nth of the nth weekday of the month,
+	// negative Nth is counted from the end of the month (-1 is the last)
+	Weekday time.Weekday
+	Nth     int
+
+	// Offset in days from the Easter Sunday of the easter relative date
+	Offset int
+}
+
+// FixedHoliday returns the rule of the same date every year
+func FixedHoliday(name string, month time.Month, day int) HolidayRule {
+	return HolidayRule{Name: name, Kind: HolidayFixedDate, Month: month, Day: day}
+}
+
+// NthWeekdayHoliday returns the rule of the nth weekday of the month,
+// e.g. the 4th Thursday of November or the last (-1) Monday of May
+func NthWeekdayHoliday(name string, month time.Month, weekDay time.Weekday, nth int) HolidayRule {
+	return HolidayRule{Name: name, Kind: HolidayNthWeekday, Month: month, Weekday: weekDay, Nth: nth}
+}
+
+// EasterHoliday returns the rule of the date relative to the western Easter Sunday
+func EasterHoliday(name string, offset int) HolidayRule {
+	return HolidayRule{Name: name, Kind: HolidayEasterRelative, Offset: offset}
+}
+
+// Date returns the date of the holiday in the year as midnight of UTC.
+// False is returned if the year has no such date (Feb 29 or 5th weekday).
+func (r HolidayRule) Date(year int) (time.Time, bool) {
+	switch r.Kind {
+	case HolidayFixedDate:
+		date := time.Date(year, r.Month, r.Day, 0, 0, 0, 0, time.UTC)
+		return date, date.Month() == r.Month && date.Day() == r.Day
+	case HolidayNthWeekday:
+		return nthWeekday(year, r.Month, r.Weekday, r.Nth)
+	case HolidayEasterRelative:
+		return EasterDate(year).AddDate(0, 0, r.Offset), true
+	}
+	return time.Time{}, false
+}
+
+// IsHoliday implements HolidayCalendar for the single rule
+func (r HolidayRule) IsHoliday(t time.Time) (string, bool) {
+	key := dateKey(t)
+	// Easter relative dates could move to the neighbour year
+	for year := t.Year() - 1; year <= t.Year()+1; year++ {
+		if date, ok := r.Date(year); ok && dateKey(date) == key {
+			return r.Name, true
+		}
+	}
+	return "", false
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (r HolidayRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.toJSON())
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (r *HolidayRule) UnmarshalJSON(data []byte) error {
+	var rule holidayRuleJSON
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return err
+	}
+	return r.fromJSON(&rule)
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (r HolidayRule) MarshalYAML() (any, error) {
+	return r.toJSON(), nil
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (r *HolidayRule) UnmarshalYAML(node *yaml.Node) error {
+	var rule holidayRuleJSON
+	if err := node.Decode(&rule); err != nil {
+		return err
+	}
+	return r.fromJSON(&rule)
+}
+
+//easyjson:json
+type holidayRuleJSON struct {
+	Name    string `json:"name" yaml:"name"`
+	Date    string `json:"date,omitempty" yaml:"date,omitempty"`
+	Month   int    `json:"month,omitempty" yaml:"month,omitempty"`
+	Weekday string `json:"weekday,omitempty" yaml:"weekday,omitempty"`
+	Nth     int    `json:"nth,omitempty" yaml:"nth,omitempty"`
+	Easter  *int   `json:"easter,omitempty" yaml:"easter,omitempty"`
+}
+
+func (r *HolidayRule) toJSON() *holidayRuleJSON {
+	rule := &holidayRuleJSON{Name: r.Name}
+	switch r.Kind {
+	case HolidayFixedDate:
+		rule.Date = fmt.Sprintf("%02d-%02d", r.Month, r.Day)
+	case HolidayNthWeekday:
+		rule.Month = int(r.Month)
+		rule.Weekday = strings.ToLower(r.Weekday.String()[:3])
+		rule.Nth = r.Nth
+	case HolidayEasterRelative:
+		offset := r.Offset
+		rule.Easter = &offset
+	}
+	return rule
+}
+
+func (r *HolidayRule) fromJSON(rule *holidayRuleJSON) error {
+	switch {
+	case rule.Date != "":
+		date, err := time.Parse(HolidayDateFormat, rule.Date)
+		if err != nil {
+			return fmt.Errorf("[holidays] invalid date %q of %q: %w", rule.Date, rule.Name, err)
+		}
+		*r = FixedHoliday(rule.Name, date.Month(), date.Day())
+	case rule.Weekday != "":
+		weekDay, ok := weekDayNames[strings.ToLower(rule.Weekday)]
+		if !ok {
+			return fmt.Errorf("[holidays] invalid weekday %q of %q", rule.Weekday, rule.Name)
+		}
+		if rule.Month < 1 || rule.Month > 12 {
+			return fmt.Errorf("[holidays] invalid month %d of %q", rule.Month, rule.Name)
+		}
+		if rule.Nth == 0 || rule.Nth < -5 || rule.Nth > 5 {
+			return fmt.Errorf("[holidays] invalid nth %d of %q", rule.Nth, rule.Name)
+		}
+		*r = NthWeekdayHoliday(rule.Name, time.Month(rule.Month), weekDay, rule.Nth)
+	case rule.Easter != nil:
+		*r = EasterHoliday(rule.Name, *rule.Easter)
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidHolidayRule, rule.Name)
+	}
+	return nil
+}
+
+// HolidayRules is the offline calendar of the holiday rules
+type HolidayRules struct {
+	Name  string        `json:"name,omitempty" yaml:"name,omitempty"`
+	Rules []HolidayRule `json:"holidays" yaml:"holidays"`
+}
+
+// HolidayRulesByJSON decodes JSON definition of the calendar
+func HolidayRulesByJSON(data []byte) (rules *HolidayRules, err error) {
+	rules = &HolidayRules{}
+	if err = json.Unmarshal(data, rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// HolidayRulesByYAML decodes YAML definition of the calendar
+func HolidayRulesByYAML(data []byte) (rules *HolidayRules, err error) {
+	rules = &HolidayRules{}
+	if err = yaml.Unmarshal(data, rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// LoadHolidayRules reads the calendar definition from the file,
+// files with .json extension are decoded as JSON, others as YAML
+func LoadHolidayRules(filename string) (*HolidayRules, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(strings.ToLower(filename), ".json") {
+		return HolidayRulesByJSON(data)
+	}
+	return HolidayRulesByYAML(data)
+}
+
+// IsHoliday implements HolidayCalendar, the first matched rule wins
+func (c *HolidayRules) IsHoliday(t time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	for _, rule := range c.Rules {
+		if name, ok := rule.IsHoliday(t); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Add rules to the calendar
+func (c *HolidayRules) Add(rules ...HolidayRule) *HolidayRules {
+	c.Rules = append(c.Rules, rules...)
+	return c
+}
+
+// HolidayCalendars combines several calendars, the first matched calendar wins
+type HolidayCalendars []HolidayCalendar
+
+// IsHoliday implements HolidayCalendar
+func (cs HolidayCalendars) IsHoliday(t time.Time) (string, bool) {
+	for _, c := range cs {
+		if name, ok := c.IsHoliday(t); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// EasterDate returns the western (Gregorian) Easter Sunday of the year as midnight of UTC
+func EasterDate(year int) time.Time {
+	// Anonymous Gregorian algorithm (Meeus/Jones/Butcher)
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday returns the nth weekday of the month, negative nth is counted from the end
+func nthWeekday(year int, month time.Month, weekDay time.Weekday, nth int) (time.Time, bool) {
+	var date time.Time
+	switch {
+	case nth > 0:
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		date = first.AddDate(0, 0, (int(weekDay-first.Weekday())+7)%7+(nth-1)*7)
+	case nth < 0:
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		date = last.AddDate(0, 0, -((int(last.Weekday()-weekDay)+7)%7)+(nth+1)*7)
+	default:
+		return time.Time{}, false
+	}
+	return date, date.Month() == month
+}
+
+var (
+	_ HolidayCalendar  = HolidayRule{}
+	_ HolidayCalendar  = (*HolidayRules)(nil)
+	_ HolidayCalendar  = HolidayCalendars(nil)
+	_ json.Marshaler   = HolidayRule{}
+	_ json.Unmarshaler = (*HolidayRule)(nil)
+	_ yaml.Marshaler   = HolidayRule{}
+	_ yaml.Unmarshaler = (*HolidayRule)(nil)
+)
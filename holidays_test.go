@@ -0,0 +1,190 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEasterDate(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected time.Time
+	}{
+		{year: 2024, expected: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{year: 2025, expected: time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC)},
+		{year: 2026, expected: time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC)},
+		{year: 2038, expected: time.Date(2038, 4, 25, 0, 0, 0, 0, time.UTC)},
+		{year: 2285, expected: time.Date(2285, 3, 22, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if date := EasterDate(tt.year); !date.Equal(tt.expected) {
+			t.Errorf("EasterDate(%d) = %v, expected %v", tt.year, date, tt.expected)
+		}
+	}
+}
+
+func TestHolidayRule_Date(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     HolidayRule
+		year     int
+		expected time.Time
+		ok       bool
+	}{
+		{
+			name:     "fixed date",
+			rule:     FixedHoliday("Christmas Day", time.December, 25),
+			year:     2026,
+			expected: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name: "no leap day",
+			rule: FixedHoliday("Leap Day", time.February, 29),
+			year: 2026,
+		},
+		{
+			name:     "4th thursday",
+			rule:     NthWeekdayHoliday("Thanksgiving", time.November, time.Thursday, 4),
+			year:     2026,
+			expected: time.Date(2026, 11, 26, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "last monday",
+			rule:     NthWeekdayHoliday("Memorial Day", time.May, time.Monday, -1),
+			year:     2026,
+			expected: time.Date(2026, 5, 25, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name: "no 5th friday",
+			rule: NthWeekdayHoliday("5th Friday", time.February, time.Friday, 5),
+			year: 2026,
+		},
+		{
+			name:     "good friday",
+			rule:     EasterHoliday("Good Friday", -2),
+			year:     2026,
+			expected: time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, ok := tt.rule.Date(tt.year)
+			if ok != tt.ok {
+				t.Fatalf("Date(%d) ok = %v, expected %v", tt.year, ok, tt.ok)
+			}
+			if ok && !date.Equal(tt.expected) {
+				t.Errorf("Date(%d) = %v, expected %v", tt.year, date, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHolidayRules_Load(t *testing.T) {
+	definition := `
+name: Example
+holidays:
+  - name: New Year's Day
+    date: "01-01"
+  - name: Thanksgiving
+    month: 11
+    weekday: thu
+    nth: 4
+  - name: Easter Monday
+    easter: 1
+`
+	filename := filepath.Join(t.TempDir(), "holidays.yaml")
+	if err := os.WriteFile(filename, []byte(definition), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadHolidayRules(filename)
+	if err != nil {
+		t.Fatalf("LoadHolidayRules() error = %v", err)
+	}
+	if len(rules.Rules) != 3 {
+		t.Fatalf("LoadHolidayRules() = %v, expected 3 rules", rules.Rules)
+	}
+
+	for _, date := range []time.Time{
+		time.Date(2027, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 11, 26, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 4, 6, 10, 0, 0, 0, time.UTC),
+	} {
+		if _, ok := rules.IsHoliday(date); !ok {
+			t.Errorf("IsHoliday(%v) = false, expected true", date)
+		}
+	}
+	if name, ok := rules.IsHoliday(time.Date(2026, 4, 7, 10, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("IsHoliday() = %q, expected no holiday", name)
+	}
+
+	// JSON round trip keeps the rules
+	data, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := HolidayRulesByJSON(data)
+	if err != nil {
+		t.Fatalf("HolidayRulesByJSON() error = %v", err)
+	}
+	for i, rule := range decoded.Rules {
+		if rule != rules.Rules[i] {
+			t.Errorf("decoded rule %d = %v, expected %v", i, rule, rules.Rules[i])
+		}
+	}
+
+	if _, err = HolidayRulesByJSON([]byte(`{"holidays":[{"name":"broken"}]}`)); err == nil {
+		t.Error("expected error of the rule without date")
+	}
+}
+
+func TestCalendarHours_Holidays(t *testing.T) {
+	holidays := (&HolidayRules{}).Add(FixedHoliday("Christmas Day", time.December, 25))
+	calendar := CalendarHours{
+		Hours:        MustHoursByRanges("Mon-Fri 9-17"),
+		Holidays:     holidays,
+		HolidayHours: RangeDayHours(10, 12),
+	}
+	calendar.SetDate(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC).AddDate(1, 0, 0), NoDayHours)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected bool
+	}{
+		{name: "holiday hours", t: time.Date(2026, 12, 25, 11, 0, 0, 0, time.UTC), expected: true},
+		{name: "closed on holiday", t: time.Date(2026, 12, 25, 9, 0, 0, 0, time.UTC), expected: false},
+		{name: "override wins", t: time.Date(2027, 12, 25, 11, 0, 0, 0, time.UTC), expected: false},
+		{name: "weekly hours", t: time.Date(2026, 12, 24, 9, 0, 0, 0, time.UTC), expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if res := calendar.TestTime(tt.t); res != tt.expected {
+				t.Errorf("TestTime(%v) = %v, expected %v", tt.t, res, tt.expected)
+			}
+		})
+	}
+
+	data, err := json.Marshal(calendar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := CalendarHoursByJSON(data)
+	if err != nil {
+		t.Fatalf("CalendarHoursByJSON() error = %v", err)
+	}
+	if !decoded.Equal(calendar) {
+		t.Errorf("decoded = %v, expected %v", decoded, calendar)
+	}
+	if name, ok := decoded.Holiday(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)); !ok || name != "Christmas Day" {
+		t.Errorf("decoded Holiday() = %q, %v, expected Christmas Day", name, ok)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -25,14 +26,19 @@ const daysBitMask = byte(0x7f)
 // Hours type
 type Hours []byte
 
-// HoursByString returns hours value or error
+// HoursByString returns hours value or error.
+// The compact format with the CompactHoursPrefix is accepted as well.
 func HoursByString(s string) (h Hours, err error) {
 	if s == "" || s == "*" || s == AllActiveHoursString || s == ActiveWeekHoursString {
 		return nil, nil
 	}
 
+	if strings.HasPrefix(s, CompactHoursPrefix) {
+		return HoursByCompactString(s)
+	}
+
 	if len(s) > 7*24 {
-		err = ErrTooMuchHoursForDecode
+		return nil, ErrTooMuchHoursForDecode
 	}
 
 	h = make([]byte, 24)
@@ -95,7 +101,8 @@ func (h *Hours) Scan(value any) (err error) {
 	return
 }
 
-// Merge from another hours
+// Merge from another hours in place.
+// The empty receiver is all active already and stays unchanged, use Union to get a new table.
 func (h Hours) Merge(h2 Hours) {
 	if len(h) < 1 {
 		return
@@ -266,6 +273,45 @@ func (h Hours) Clone() Hours {
 	return newHours
 }
 
+// Rotate returns a copy of hours shifted by n hours across the week.
+// Positive n moves every active hour later, negative n moves it earlier,
+// hours which cross the week boundary wrap around (Saturday 23h + 1 = Sunday 0h).
+//
+// To convert a table defined at UTC+3 into UTC use Rotate(-3).
+func (h Hours) Rotate(n int) Hours {
+	if len(h) < 1 {
+		return nil
+	}
+
+	n %= 7 * 24
+	if n < 0 {
+		n += 7 * 24
+	}
+
+	var (
+		days     = n / 24
+		hours    = n % 24
+		newHours = make(Hours, 24)
+	)
+	for hour := 0; hour < 24 && hour < len(h); hour++ {
+		target, carry := hour+hours, 0
+		if target >= 24 {
+			target, carry = target-24, 1
+		}
+		newHours[target] |= rotateWeekDays(h[hour], days+carry)
+	}
+	return newHours
+}
+
+// rotateWeekDays shifts the days of week mask by n days with wrap from Saturday to Sunday
+func rotateWeekDays(mask byte, n int) byte {
+	mask &= daysBitMask
+	if n %= 7; n == 0 {
+		return mask
+	}
+	return (mask<<byte(n) | mask>>byte(7-n)) & daysBitMask
+}
+
 var (
 	_ json.Marshaler   = (Hours)(nil)
 	_ json.Unmarshaler = (*Hours)(nil)
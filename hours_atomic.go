@@ -0,0 +1,146 @@
+package hourstable
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+)
+
+// packedWeek is the table of 7*24 bits, bit i%64 of the word i/64 is the hour i
+// of the week (day*24+hour), the same order as the binary format
+type packedWeek [3]uint64
+
+var allActiveWeek = packWeek(nil)
+
+func packWeek(h Hours) (week packedWeek) {
+	var (
+		table = packHours(h)
+		buff  [24]byte
+	)
+	copy(buff[:], table[:])
+	for i := range week {
+		week[i] = binary.LittleEndian.Uint64(buff[i*8:])
+	}
+	return week
+}
+
+func (w *packedWeek) hours() Hours {
+	if *w == allActiveWeek {
+		return nil
+	}
+	var buff [24]byte
+	for i, word := range w {
+		binary.LittleEndian.PutUint64(buff[i*8:], word)
+	}
+	return unpackHours(buff[:binaryTableSize])
+}
+
+// AtomicHours is the hours table safe for the concurrent access.
+// The packed table is immutable and replaced by the pointer (copy-on-write),
+// so the readers never lock and never see the partially updated table.
+// The zero value is the all active table. AtomicHours must not be copied after first use.
+type AtomicHours struct {
+	week atomic.Pointer[packedWeek]
+}
+
+// NewAtomicHours returns the concurrent table with the hours
+func NewAtomicHours(h Hours) *AtomicHours {
+	a := &AtomicHours{}
+	a.Store(h)
+	return a
+}
+
+// Load returns the copy of the current hours table
+func (a *AtomicHours) Load() Hours {
+	return a.load().hours()
+}
+
+// Store the copy of the hours table
+func (a *AtomicHours) Store(h Hours) {
+	week := packWeek(h)
+	a.week.Store(&week)
+}
+
+// CompareAndSwap replaces the table by new if the current table is equal to old
+func (a *AtomicHours) CompareAndSwap(old, new Hours) bool {
+	var (
+		oldWeek = packWeek(old)
+		newWeek = packWeek(new)
+	)
+	for {
+		current := a.week.Load()
+		if weekOf(current) != oldWeek {
+			return false
+		}
+		if a.week.CompareAndSwap(current, &newWeek) {
+			return true
+		}
+	}
+}
+
+// Update the table by fn and returns the new table.
+// fn receives the copy of the current table and could be called
+// several times if the table is changed concurrently.
+func (a *AtomicHours) Update(fn func(h Hours) Hours) Hours {
+	for {
+		current := a.week.Load()
+		week := weekOf(current)
+		h := fn(week.hours())
+		if week = packWeek(h); a.week.CompareAndSwap(current, &week) {
+			return h
+		}
+	}
+}
+
+// TestHour hour
+func (a *AtomicHours) TestHour(weekDay time.Weekday, hour byte) bool {
+	if weekDay < 0 || weekDay > time.Saturday || hour > 23 {
+		return false
+	}
+	i := int(weekDay)*24 + int(hour)
+	return a.load()[i/64]&(1<<(i%64)) != 0
+}
+
+// TestTime hour
+func (a *AtomicHours) TestTime(t time.Time) bool {
+	return a.TestHour(t.Weekday(), byte(t.Hour()))
+}
+
+// SetHour as active or no
+func (a *AtomicHours) SetHour(weekDay time.Weekday, hour byte, active bool) {
+	if weekDay < 0 || weekDay > time.Saturday || hour > 23 {
+		return
+	}
+	i := int(weekDay)*24 + int(hour)
+	for {
+		current := a.week.Load()
+		week := weekOf(current)
+		if active {
+			week[i/64] |= 1 << (i % 64)
+		} else {
+			week[i/64] &^= 1 << (i % 64)
+		}
+		if a.week.CompareAndSwap(current, &week) {
+			return
+		}
+	}
+}
+
+// String implementation of fmt.Stringer
+func (a *AtomicHours) String() string {
+	return a.Load().String()
+}
+
+func (a *AtomicHours) load() *packedWeek {
+	if week := a.week.Load(); week != nil {
+		return week
+	}
+	return &allActiveWeek
+}
+
+func weekOf(week *packedWeek) packedWeek {
+	if week == nil {
+		return allActiveWeek
+	}
+	return *week
+}
@@ -0,0 +1,140 @@
+package hourstable
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAtomicHours(t *testing.T) {
+	var (
+		a        AtomicHours
+		business = MustHoursByRanges("Mon-Fri 9-17")
+	)
+	if h := a.Load(); h != nil || !a.TestHour(time.Sunday, 0) {
+		t.Errorf("zero value Load() = %v, expected all active", h)
+	}
+
+	a.Store(business)
+	tests := []struct {
+		weekDay time.Weekday
+		hour    byte
+		active  bool
+	}{
+		{weekDay: time.Monday, hour: 9, active: true},
+		{weekDay: time.Friday, hour: 16, active: true},
+		{weekDay: time.Friday, hour: 17, active: false},
+		{weekDay: time.Sunday, hour: 12, active: false},
+		{weekDay: time.Monday, hour: 24, active: false},
+	}
+	for _, tt := range tests {
+		if active := a.TestHour(tt.weekDay, tt.hour); active != tt.active {
+			t.Errorf("TestHour(%s, %d) = %v, expected %v", tt.weekDay, tt.hour, active, tt.active)
+		}
+	}
+	if h := a.Load(); !h.Equal(business) || a.String() != business.String() {
+		t.Errorf("Load() = %v, expected %v", h, business)
+	}
+
+	// The table returned by Load is the copy
+	h := a.Load()
+	h.SetHour(time.Sunday, 0, true)
+	if a.TestHour(time.Sunday, 0) {
+		t.Error("Load() must return the copy of the table")
+	}
+
+	if a.CompareAndSwap(nil, make(Hours, 24)) {
+		t.Error("CompareAndSwap() must fail for the different table")
+	}
+	if !a.CompareAndSwap(MustHoursByString(business.String()), nil) || a.Load() != nil {
+		t.Errorf("CompareAndSwap() = %v, expected all active", a.Load())
+	}
+
+	updated := a.Update(func(h Hours) Hours {
+		h = make(Hours, 24)
+		h.SetHour(time.Saturday, 10, true)
+		return h
+	})
+	if !a.Load().Equal(updated) || !a.TestHour(time.Saturday, 10) || a.TestHour(time.Saturday, 11) {
+		t.Errorf("Update() = %v, expected %v", a.Load(), updated)
+	}
+
+	a.SetHour(time.Saturday, 10, false)
+	if !a.Load().IsNoActive() {
+		t.Errorf("SetHour() = %v, expected no active", a.Load())
+	}
+	if !NewAtomicHours(business).TestTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Error("TestTime() = false, expected true")
+	}
+}
+
+func TestAtomicHours_Concurrent(t *testing.T) {
+	var (
+		a     = NewAtomicHours(make(Hours, 24))
+		wg    sync.WaitGroup
+		done  = make(chan struct{})
+		ready sync.WaitGroup
+	)
+
+	// Readers run in parallel with the writers without locks
+	for i := 0; i < 4; i++ {
+		ready.Add(1)
+		go func() {
+			defer ready.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_ = a.TestTime(time.Now())
+				_ = a.Load()
+			}
+		}()
+	}
+
+	// Every writer sets own hours, the concurrent updates must not be lost
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		wg.Add(2)
+		go func(day time.Weekday) {
+			defer wg.Done()
+			for hour := byte(0); hour < 12; hour++ {
+				a.SetHour(day, hour, true)
+			}
+		}(day)
+		go func(day time.Weekday) {
+			defer wg.Done()
+			for hour := byte(12); hour < 24; hour++ {
+				a.Update(func(h Hours) Hours {
+					h.SetHour(day, hour, true)
+					return h
+				})
+			}
+		}(day)
+	}
+	wg.Wait()
+	close(done)
+	ready.Wait()
+
+	if h := a.Load(); !h.IsAllActive() {
+		t.Errorf("Load() = %v, expected all active", h)
+	}
+}
+
+func Benchmark_AtomicHours(b *testing.B) {
+	var (
+		a   = NewAtomicHours(MustHoursByRanges("Mon-Fri 9-17"))
+		now = time.Now()
+	)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		var i = 0
+		for pb.Next() {
+			_ = a.TestHour(time.Weekday(i%7), byte(i%24))
+			_ = a.TestTime(now)
+			i++
+		}
+	})
+}
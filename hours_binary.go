@@ -0,0 +1,215 @@
+package hourstable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Binary format errors
+var (
+	ErrInvalidBinaryHours      = errors.New("[hours] invalid binary hours data")
+	ErrUnsupportedBinaryFormat = errors.New("[hours] unsupported binary hours format version")
+)
+
+// Binary format of the hours is the one byte marker of the special table
+// or the packed table of 7*24 bits, the length selects the kind of the data.
+// The high 4 bits of the marker is the version of the format.
+//
+//	0x10     - all hours are active
+//	0x11     - all hours are inactive
+//	21 bytes - bit N of the table is the hour N of the week started from Sunday 0h,
+//	           bits are numbered from the lowest bit of the first byte
+const (
+	binaryVersionMask = 0xf0
+	binaryVersion1    = 0x10
+	binaryAllActive   = binaryVersion1 | 0x00
+	binaryNoActive    = binaryVersion1 | 0x01
+	binaryTableSize   = 7 * 24 / 8
+)
+
+// HoursByBinary decodes the binary format of the hours
+func HoursByBinary(data []byte) (Hours, error) {
+	switch len(data) {
+	case 1:
+		switch data[0] {
+		case binaryAllActive:
+			return nil, nil
+		case binaryNoActive:
+			return make(Hours, 24), nil
+		}
+		return nil, ErrUnsupportedBinaryFormat
+	case binaryTableSize:
+		return unpackHours(data), nil
+	}
+	return nil, ErrInvalidBinaryHours
+}
+
+// AppendBinary appends the binary format of the hours to b
+func (h Hours) AppendBinary(b []byte) ([]byte, error) {
+	switch {
+	case h.IsAllActive():
+		return append(b, binaryAllActive), nil
+	case h.IsNoActive():
+		return append(b, binaryNoActive), nil
+	}
+	table := packHours(h)
+	return append(b, table[:]...), nil
+}
+
+// MarshalBinary implements the functionality of encoding.BinaryMarshaler interface
+func (h Hours) MarshalBinary() ([]byte, error) {
+	return h.AppendBinary(make([]byte, 0, binaryTableSize))
+}
+
+// UnmarshalBinary implements the functionality of encoding.BinaryUnmarshaler interface
+func (h *Hours) UnmarshalBinary(data []byte) error {
+	newHours, err := HoursByBinary(data)
+	if err != nil {
+		return err
+	}
+	*h = newHours
+	return nil
+}
+
+// AppendBinary appends the binary format of the hours to b
+func (h HoursObject) AppendBinary(b []byte) ([]byte, error) {
+	return Hours(h).AppendBinary(b)
+}
+
+// MarshalBinary implements the functionality of encoding.BinaryMarshaler interface
+func (h HoursObject) MarshalBinary() ([]byte, error) {
+	return Hours(h).MarshalBinary()
+}
+
+// UnmarshalBinary implements the functionality of encoding.BinaryUnmarshaler interface
+func (h *HoursObject) UnmarshalBinary(data []byte) error {
+	return (*Hours)(h).UnmarshalBinary(data)
+}
+
+// BinaryHours is the Hours stored in database/sql in the binary format,
+// JSON and YAML use the same format as Hours
+type BinaryHours Hours
+
+// String implementation of fmt.Stringer
+func (h BinaryHours) String() string {
+	return Hours(h).String()
+}
+
+// Value implementation of valuer for database/sql
+func (h BinaryHours) Value() (driver.Value, error) {
+	return Hours(h).MarshalBinary()
+}
+
+// Scan - Implement the database/sql scanner interface.
+// The text format of Hours is accepted as well to simplify the migration,
+// the markers of the binary format never clash with the text symbols
+// and the value of 21 bytes is always the binary table.
+func (h *BinaryHours) Scan(value any) (err error) {
+	if value == nil {
+		*h = nil
+		return nil
+	}
+
+	var newHours Hours
+	switch v := value.(type) {
+	case []byte:
+		if isBinaryHours(v) {
+			newHours, err = HoursByBinary(v)
+		} else {
+			newHours, err = HoursByString(string(v))
+		}
+		if err == nil {
+			*h = BinaryHours(newHours)
+		}
+	case string:
+		if newHours, err = HoursByString(v); err == nil {
+			*h = BinaryHours(newHours)
+		}
+	default:
+		err = fmt.Errorf("[hours] unsupported decode type %T", value)
+	}
+	return
+}
+
+// MarshalBinary implements the functionality of encoding.BinaryMarshaler interface
+func (h BinaryHours) MarshalBinary() ([]byte, error) {
+	return Hours(h).MarshalBinary()
+}
+
+// UnmarshalBinary implements the functionality of encoding.BinaryUnmarshaler interface
+func (h *BinaryHours) UnmarshalBinary(data []byte) error {
+	return (*Hours)(h).UnmarshalBinary(data)
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (h BinaryHours) MarshalJSON() ([]byte, error) {
+	return Hours(h).MarshalJSON()
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (h *BinaryHours) UnmarshalJSON(data []byte) error {
+	return (*Hours)(h).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (h BinaryHours) MarshalYAML() (any, error) {
+	return Hours(h).MarshalYAML()
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (h *BinaryHours) UnmarshalYAML(node *yaml.Node) error {
+	return (*Hours)(h).UnmarshalYAML(node)
+}
+
+// isBinaryHours returns true if the data has the length and the marker of the binary format
+func isBinaryHours(data []byte) bool {
+	return len(data) == binaryTableSize || (len(data) == 1 && data[0]&binaryVersionMask == binaryVersion1)
+}
+
+// packHours returns the packed table of 7*24 bits, bit N is the hour N of the week started from Sunday 0h
+func packHours(h Hours) (table [binaryTableSize]byte) {
+	if len(h) < 1 {
+		for i := range table {
+			table[i] = 0xff
+		}
+		return table
+	}
+	for i := 0; i < 7*24; i++ {
+		if hour := i % 24; hour < len(h) && h[hour]&(byte(0x01)<<byte(i/24)) != 0 {
+			table[i/8] |= 1 << (i % 8)
+		}
+	}
+	return table
+}
+
+// unpackHours decodes the packed table of 7*24 bits
+func unpackHours(table []byte) Hours {
+	h := make(Hours, 24)
+	for i := 0; i < 7*24; i++ {
+		if table[i/8]&(1<<(i%8)) != 0 {
+			h[i%24] |= byte(0x01) << byte(i/24)
+		}
+	}
+	return h
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (Hours)(nil)
+	_ encoding.BinaryUnmarshaler = (*Hours)(nil)
+	_ encoding.BinaryMarshaler   = (HoursObject)(nil)
+	_ encoding.BinaryUnmarshaler = (*HoursObject)(nil)
+	_ encoding.BinaryMarshaler   = (BinaryHours)(nil)
+	_ encoding.BinaryUnmarshaler = (*BinaryHours)(nil)
+	_ json.Marshaler             = (BinaryHours)(nil)
+	_ json.Unmarshaler           = (*BinaryHours)(nil)
+	_ yaml.Marshaler             = (BinaryHours)(nil)
+	_ yaml.Unmarshaler           = (*BinaryHours)(nil)
+	_ driver.Valuer              = (BinaryHours)(nil)
+	_ sql.Scanner                = (*BinaryHours)(nil)
+)
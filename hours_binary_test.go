@@ -0,0 +1,127 @@
+package hourstable
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+func TestHours_MarshalBinary(t *testing.T) {
+	type binaryTest struct {
+		name   string
+		hours  Hours
+		length int
+	}
+	tests := []binaryTest{
+		{name: "all active table", hours: bytes.Repeat([]byte{daysBitMask}, 24), length: 1},
+		{name: "garbage bits of all active", hours: bytes.Repeat([]byte{0xff}, 24), length: 1},
+		{name: "garbage bits", hours: Hours{0x80, 0xc1, 0x80}, length: binaryTableSize},
+		{name: "garbage bits of no active", hours: bytes.Repeat([]byte{0x80}, 24), length: 1},
+		{name: "short table", hours: Hours{0x01, 0x40}, length: binaryTableSize},
+	}
+	for _, table := range hourstest.Tables() {
+		length := binaryTableSize
+		if h := Hours(table.Hours); h.IsAllActive() || h.IsNoActive() {
+			length = 1
+		}
+		tests = append(tests, binaryTest{name: table.Name, hours: table.Hours, length: length})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.hours.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() error = %v", err)
+			}
+			if len(data) != tt.length {
+				t.Errorf("MarshalBinary() length = %d, expected %d", len(data), tt.length)
+			}
+			var decoded Hours
+			if err = decoded.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary() error = %v", err)
+			}
+			if !decoded.Equal(tt.hours) {
+				t.Errorf("UnmarshalBinary() = %v, expected %v", decoded, tt.hours)
+			}
+			if decoded.IsNoActive() != tt.hours.IsNoActive() {
+				t.Errorf("UnmarshalBinary() IsNoActive = %v, expected %v", decoded.IsNoActive(), tt.hours.IsNoActive())
+			}
+		})
+	}
+
+	// Sunday 0h is the first bit, Saturday 23h is the last one
+	data, _ := MustHoursByRanges("Sun 0-1; Sat 23-24").MarshalBinary()
+	expected := append([]byte{0x01}, make([]byte, 19)...)
+	expected = append(expected, 0x80)
+	if !bytes.Equal(data, expected) {
+		t.Errorf("MarshalBinary() = %x, expected %x", data, expected)
+	}
+}
+
+func TestHoursByBinary_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{name: "empty", data: nil, err: ErrInvalidBinaryHours},
+		{name: "unknown version", data: []byte{0x20}, err: ErrUnsupportedBinaryFormat},
+		{name: "unknown kind", data: []byte{0x1f}, err: ErrUnsupportedBinaryFormat},
+		{name: "short table", data: make([]byte, binaryTableSize-1), err: ErrInvalidBinaryHours},
+		{name: "long table", data: make([]byte, binaryTableSize+1), err: ErrInvalidBinaryHours},
+		{name: "tail of marker", data: []byte{binaryAllActive, 0x00}, err: ErrInvalidBinaryHours},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := HoursByBinary(tt.data); err != tt.err {
+				t.Errorf("HoursByBinary() error = %v, expected %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestBinaryHours_ValueScan(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17")
+
+	value, err := BinaryHours(business).Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	data, ok := value.([]byte)
+	if !ok || len(data) != binaryTableSize {
+		t.Fatalf("Value() = %v, expected %d bytes", value, binaryTableSize)
+	}
+
+	for _, value := range []any{data, []byte(business.String()), business.String()} {
+		var h BinaryHours
+		if err = h.Scan(value); err != nil {
+			t.Fatalf("Scan(%v) error = %v", value, err)
+		}
+		if !Hours(h).Equal(business) {
+			t.Errorf("Scan(%v) = %v, expected %v", value, h, business)
+		}
+	}
+
+	// The one byte markers never clash with the text
+	for _, tt := range []struct {
+		value []byte
+		hours Hours
+	}{
+		{value: []byte{binaryNoActive}, hours: make(Hours, 24)},
+		{value: []byte{binaryAllActive}, hours: nil},
+		{value: []byte("*"), hours: nil},
+		{value: []byte("1"), hours: MustHoursByRanges("Sun 0-1")},
+	} {
+		var h BinaryHours
+		if err = h.Scan(tt.value); err != nil || !Hours(h).Equal(tt.hours) || Hours(h).IsNoActive() != tt.hours.IsNoActive() {
+			t.Errorf("Scan(%q) = %v, %v, expected %v", tt.value, h, err, tt.hours)
+		}
+	}
+
+	var o HoursObject
+	if err = o.UnmarshalBinary(data); err != nil || !o.Equal(business) {
+		t.Errorf("HoursObject.UnmarshalBinary() = %v, %v, expected %v", o, err, business)
+	}
+}
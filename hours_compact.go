@@ -0,0 +1,51 @@
+package hourstable
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CompactHoursPrefix of the compact text format of the hours
+const CompactHoursPrefix = "h1:"
+
+// ErrInvalidCompactHours tells that the compact hours string is broken
+var ErrInvalidCompactHours = errors.New("[hours] invalid compact hours string")
+
+// compactEncoding of the packed table, safe for URL query, cookies and JWT claims
+var compactEncoding = base64.RawURLEncoding
+
+// HoursByCompactString decodes the compact text format of the hours:
+// "h1:" prefix followed by the URL safe base64 (without padding)
+// of the packed table of 7*24 bits of the binary format
+func HoursByCompactString(s string) (Hours, error) {
+	if !strings.HasPrefix(s, CompactHoursPrefix) {
+		return nil, ErrInvalidCompactHours
+	}
+	data := s[len(CompactHoursPrefix):]
+	if compactEncoding.DecodedLen(len(data)) != binaryTableSize {
+		return nil, ErrInvalidCompactHours
+	}
+	var table [binaryTableSize]byte
+	if _, err := compactEncoding.Decode(table[:], []byte(data)); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCompactHours, err)
+	}
+	h := unpackHours(table[:])
+	if h.IsAllActive() {
+		return nil, nil
+	}
+	return h, nil
+}
+
+// CompactString returns the compact text format of the hours, 31 symbols long,
+// accepted by HoursByString, UnmarshalJSON and UnmarshalYAML
+func (h Hours) CompactString() string {
+	table := packHours(h)
+	return CompactHoursPrefix + compactEncoding.EncodeToString(table[:])
+}
+
+// CompactString returns the compact text format of the hours, see Hours.CompactString
+func (h HoursObject) CompactString() string {
+	return Hours(h).CompactString()
+}
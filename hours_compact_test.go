@@ -0,0 +1,73 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+func TestHours_CompactString(t *testing.T) {
+	type compactTest struct {
+		name  string
+		hours Hours
+	}
+	tests := []compactTest{
+		{name: "garbage bits", hours: Hours{0x80, 0xc1, 0xff}},
+		{name: "short table", hours: Hours{0x01, 0x40}},
+	}
+	for _, table := range hourstest.Tables() {
+		tests = append(tests, compactTest{name: table.Name, hours: table.Hours})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.hours.CompactString()
+			if len(s) != 31 {
+				t.Errorf("CompactString() = %q, expected 31 symbols", s)
+			}
+			if url.QueryEscape(s) != s[:2]+"%3A"+s[3:] {
+				t.Errorf("CompactString() = %q is not URL safe", s)
+			}
+
+			decoded, err := HoursByString(s)
+			if err != nil {
+				t.Fatalf("HoursByString() error = %v", err)
+			}
+			if !decoded.Equal(tt.hours) {
+				t.Errorf("HoursByString() = %v, expected %v", decoded, tt.hours)
+			}
+		})
+	}
+}
+
+func TestHours_CompactStringDecode(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17")
+	compact := business.CompactString()
+
+	var h Hours
+	if err := json.Unmarshal([]byte(`"`+compact+`"`), &h); err != nil || !h.Equal(business) {
+		t.Errorf("json.Unmarshal() = %v, %v, expected %v", h, err, business)
+	}
+	h = nil
+	if err := yaml.Unmarshal([]byte(compact), &h); err != nil || !h.Equal(business) {
+		t.Errorf("yaml.Unmarshal() = %v, %v, expected %v", h, err, business)
+	}
+	if h, err := HoursByStringStrict(compact); err != nil || !h.Equal(business) {
+		t.Errorf("HoursByStringStrict() = %v, %v, expected %v", h, err, business)
+	}
+
+	for _, s := range []string{"h1:", "h1:AAAA", compact + "A", compact[:30] + "!"} {
+		if _, err := HoursByString(s); !errors.Is(err, ErrInvalidCompactHours) {
+			t.Errorf("HoursByString(%q) error = %v, expected %v", s, err, ErrInvalidCompactHours)
+		}
+		var perr *ParseError
+		if _, err := HoursByStringStrict(s); !errors.As(err, &perr) {
+			t.Errorf("HoursByStringStrict(%q) error = %v, expected *ParseError", s, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package hourstable
+
+import (
+	"math/bits"
+	"time"
+)
+
+// ActiveHoursPerWeek returns number of active hours of the week
+func (h Hours) ActiveHoursPerWeek() int {
+	if len(h) < 1 {
+		return 7 * 24
+	}
+	count := 0
+	for hour := 0; hour < 24 && hour < len(h); hour++ {
+		count += bits.OnesCount8(h[hour] & daysBitMask)
+	}
+	return count
+}
+
+// ActiveHoursOn returns number of active hours of the week day
+func (h Hours) ActiveHoursOn(weekDay time.Weekday) int {
+	if len(h) < 1 {
+		return 24
+	}
+	var (
+		count = 0
+		mask  = byte(0x01) << byte(weekDay)
+	)
+	for hour := 0; hour < 24 && hour < len(h); hour++ {
+		if h[hour]&mask != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// ActiveDuration returns total active time between from and to.
+// The range is split by the zone transitions of the location of from,
+// every part has the constant offset, so its whole weeks are counted
+// by ActiveHoursPerWeek and its whole days by ActiveHoursOn.
+// Only the partial days at the edges are checked hour by hour.
+func (h Hours) ActiveDuration(from, to time.Time) time.Duration {
+	switch {
+	case !from.Before(to) || h.IsNoActive():
+		return 0
+	case h.IsAllActive():
+		return to.Sub(from)
+	}
+
+	var (
+		total  time.Duration
+		counts activeDurations
+	)
+	counts.perWeek = time.Duration(h.ActiveHoursPerWeek()) * time.Hour
+	for day := range counts.perDay {
+		counts.perDay[day] = time.Duration(h.ActiveHoursOn(time.Weekday(day))) * time.Hour
+	}
+	for cur := from; cur.Before(to); {
+		next := to
+		if _, zoneEnd := cur.ZoneBounds(); !zoneEnd.IsZero() && zoneEnd.Before(to) {
+			next = zoneEnd
+		}
+		total += h.activeDurationInZone(cur, next, &counts)
+		cur = next
+	}
+	return total
+}
+
+// ActiveDuration in the time zone of the table, see Hours.ActiveDuration
+func (z ZonedHours) ActiveDuration(from, to time.Time) time.Duration {
+	return z.Hours.ActiveDuration(z.Time(from), z.Time(to))
+}
+
+// ActiveHoursPerWeek returns number of active hours of the week
+func (z ZonedHours) ActiveHoursPerWeek() int {
+	return z.Hours.ActiveHoursPerWeek()
+}
+
+// ActiveHoursOn returns number of active hours of the week day
+func (z ZonedHours) ActiveHoursOn(weekDay time.Weekday) int {
+	return z.Hours.ActiveHoursOn(weekDay)
+}
+
+// activeDurations of the week and of the week days
+type activeDurations struct {
+	perWeek time.Duration
+	perDay  [7]time.Duration
+}
+
+// activeDurationInZone returns active time between from and to of the same zone offset
+func (h Hours) activeDurationInZone(from, to time.Time, counts *activeDurations) time.Duration {
+	const week = 7 * 24 * time.Hour
+	var (
+		weeks = to.Sub(from) / week
+		total = time.Duration(weeks) * counts.perWeek
+	)
+	for cur := from.Add(weeks * week); cur.Before(to); {
+		sinceDay := time.Duration(cur.Hour())*time.Hour + sinceHourStart(cur)
+		next := cur.Add(24*time.Hour - sinceDay)
+		if sinceDay == 0 && !to.Before(next) {
+			total += counts.perDay[cur.Weekday()]
+		} else {
+			if to.Before(next) {
+				next = to
+			}
+			total += h.activeDurationByHours(cur, next)
+		}
+		cur = next
+	}
+	return total
+}
+
+func (h Hours) activeDurationByHours(from, to time.Time) (total time.Duration) {
+	for cur := from; cur.Before(to); {
+		next := nextHour(cur)
+		if to.Before(next) {
+			next = to
+		}
+		if h.TestTime(cur) {
+			total += next.Sub(cur)
+		}
+		cur = next
+	}
+	return total
+}
@@ -0,0 +1,152 @@
+package hourstable
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestHours_ActiveHours(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17; Sat 10-12")
+
+	if count := business.ActiveHoursPerWeek(); count != 5*8+2 {
+		t.Errorf("ActiveHoursPerWeek() = %d, expected %d", count, 5*8+2)
+	}
+	if count := Hours(nil).ActiveHoursPerWeek(); count != 7*24 {
+		t.Errorf("ActiveHoursPerWeek() of nil = %d, expected %d", count, 7*24)
+	}
+	if count := MustHoursByString("1001100").ActiveHoursPerWeek(); count != 3 {
+		t.Errorf("ActiveHoursPerWeek() of short table = %d, expected 3", count)
+	}
+
+	for day, expected := range []int{0, 8, 8, 8, 8, 8, 2} {
+		if count := business.ActiveHoursOn(time.Weekday(day)); count != expected {
+			t.Errorf("ActiveHoursOn(%s) = %d, expected %d", time.Weekday(day), count, expected)
+		}
+	}
+}
+
+func TestHours_ActiveDuration(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17; Sat 10-12")
+
+	tests := []struct {
+		name     string
+		hours    Hours
+		from, to time.Time
+		expected time.Duration
+	}{
+		{
+			name:     "all active",
+			hours:    nil,
+			from:     time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC),
+			to:       time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC),
+			expected: 23*time.Hour + 30*time.Minute,
+		},
+		{
+			name:     "never active",
+			hours:    make(Hours, 24),
+			from:     time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC),
+			to:       time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC),
+			expected: 0,
+		},
+		{
+			name:     "reversed range",
+			hours:    business,
+			from:     time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC),
+			to:       time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC),
+			expected: 0,
+		},
+		{
+			name:     "rest of the day",
+			hours:    business,
+			from:     time.Date(2026, 1, 12, 15, 45, 0, 0, time.UTC), // Monday
+			to:       time.Date(2026, 1, 13, 0, 0, 0, 0, time.UTC),
+			expected: time.Hour + 15*time.Minute,
+		},
+		{
+			name:     "rest of the week",
+			hours:    business,
+			from:     time.Date(2026, 1, 14, 16, 30, 0, 0, time.UTC), // Wednesday
+			to:       time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+			expected: 30*time.Minute + 2*8*time.Hour + 2*time.Hour,
+		},
+		{
+			name:     "several weeks",
+			hours:    business,
+			from:     time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC), // Sunday
+			to:       time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected: 4 * 42 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := tt.hours.ActiveDuration(tt.from, tt.to); d != tt.expected {
+				t.Errorf("ActiveDuration() = %v, expected %v", d, tt.expected)
+			}
+		})
+	}
+}
+
+func TestZonedHours_ActiveDuration(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	sunday := MustHoursByRanges("Sun").In(berlin)
+
+	// The day of the DST end has 25 hours, the day of the DST start has 23 hours
+	if d := sunday.ActiveDuration(
+		time.Date(2026, 10, 24, 22, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC),
+	); d != 25*time.Hour {
+		t.Errorf("ActiveDuration() = %v, expected 25h", d)
+	}
+	if d := sunday.ActiveDuration(
+		time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC),
+	); d != 23*time.Hour {
+		t.Errorf("ActiveDuration() = %v, expected 23h", d)
+	}
+}
+
+func TestHours_ActiveDurationYear(t *testing.T) {
+	var (
+		rnd   = rand.New(rand.NewSource(1))
+		zones = []*time.Location{
+			time.UTC,
+			time.FixedZone("", 5*60*60+30*60),
+			mustLoadLocation(t, "Europe/Berlin"),
+			mustLoadLocation(t, "Australia/Lord_Howe"),
+		}
+	)
+	for _, loc := range zones {
+		for i := 0; i < 20; i++ {
+			h := make(Hours, 24)
+			for j := range h {
+				h[j] = byte(rnd.Intn(128))
+			}
+			var (
+				from = time.Date(2026, 1, 1, 0, 0, 0, 0, loc).Add(time.Duration(rnd.Int63n(int64(60 * 24 * time.Hour))))
+				to   = from.Add(time.Duration(rnd.Int63n(int64(400 * 24 * time.Hour))))
+			)
+			// The hour by hour walk is the reference of the wall clock hours
+			if d, expected := h.ActiveDuration(from, to), h.activeDurationByHours(from, to); d != expected {
+				t.Errorf("ActiveDuration(%v, %v) = %v, expected %v of %s", from, to, d, expected, h)
+			}
+		}
+	}
+}
+
+func Benchmark_ActiveDuration(b *testing.B) {
+	var (
+		hours = MustHoursByRanges("Mon-Fri 9-17; Sat 10-12")
+		from  = time.Date(2026, 1, 1, 10, 30, 0, 0, mustLoadLocation(b, "Europe/Berlin"))
+		to    = from.AddDate(1, 0, 0)
+	)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = hours.ActiveDuration(from, to)
+		}
+	})
+}
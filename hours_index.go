@@ -0,0 +1,194 @@
+package hourstable
+
+import (
+	"math/bits"
+	"time"
+)
+
+// bitmap is the dense set of positions, bit i%64 of the word i/64 is the position i
+type bitmap []uint64
+
+func (b bitmap) contains(pos uint32) bool {
+	i := int(pos / 64)
+	return i < len(b) && b[i]&(1<<(pos%64)) != 0
+}
+
+func (b *bitmap) set(pos uint32) {
+	i := int(pos / 64)
+	if i >= len(*b) {
+		if i < cap(*b) {
+			*b = (*b)[:i+1]
+		} else {
+			*b = append(*b, make(bitmap, i+1-len(*b))...)
+		}
+	}
+	(*b)[i] |= 1 << (pos % 64)
+}
+
+func (b bitmap) clear(pos uint32) {
+	if i := int(pos / 64); i < len(b) {
+		b[i] &^= 1 << (pos % 64)
+	}
+}
+
+// HoursIndex is the inverted index of the hours tables by ID.
+// Every hour of the week keeps the bitmap of IDs active at this hour,
+// so the lookup of the active IDs doesn't depend on the number of tables.
+//
+// The IDs are mapped to the dense positions of the bitmaps and the positions
+// of the removed IDs are reused, so the memory depends on the number of IDs
+// in the index and not on the values of the IDs.
+// The index is not safe for concurrent modification, the lookups
+// are safe in parallel while the index is not modified.
+type HoursIndex struct {
+	positions map[uint32]uint32 // ID -> position
+	ids       []uint32          // position -> ID
+	free      []uint32          // positions of the removed IDs
+	table     [24][7]bitmap
+}
+
+// NewHoursIndex returns the empty index
+func NewHoursIndex() *HoursIndex {
+	return &HoursIndex{positions: map[uint32]uint32{}}
+}
+
+// Add the hours table of the ID, replaces the previous table of the ID
+func (idx *HoursIndex) Add(id uint32, h Hours) {
+	if idx.positions == nil {
+		idx.positions = map[uint32]uint32{}
+	}
+	pos, ok := idx.positions[id]
+	switch {
+	case ok:
+		idx.clear(pos)
+	case len(idx.free) > 0:
+		pos = idx.free[len(idx.free)-1]
+		idx.free = idx.free[:len(idx.free)-1]
+		idx.ids[pos] = id
+	default:
+		pos = uint32(len(idx.ids))
+		idx.ids = append(idx.ids, id)
+	}
+	idx.positions[id] = pos
+	for hour := range idx.table {
+		mask := daysBitMask
+		if len(h) > 0 {
+			if hour >= len(h) {
+				break
+			}
+			mask = h[hour] & daysBitMask
+		}
+		// The weekday mask of the hour selects the bitmaps directly
+		for mask != 0 {
+			idx.table[hour][bits.TrailingZeros8(mask)].set(pos)
+			mask &= mask - 1
+		}
+	}
+}
+
+// Update the hours table of the ID, returns false if the ID is not in the index
+func (idx *HoursIndex) Update(id uint32, h Hours) bool {
+	if !idx.Has(id) {
+		return false
+	}
+	idx.Add(id, h)
+	return true
+}
+
+// Remove the ID from the index, returns false if the ID is not in the index
+func (idx *HoursIndex) Remove(id uint32) bool {
+	pos, ok := idx.positions[id]
+	if !ok {
+		return false
+	}
+	idx.clear(pos)
+	delete(idx.positions, id)
+	idx.free = append(idx.free, pos)
+	return true
+}
+
+// Has returns true if the ID is in the index
+func (idx *HoursIndex) Has(id uint32) bool {
+	_, ok := idx.positions[id]
+	return ok
+}
+
+// Len returns number of IDs in the index
+func (idx *HoursIndex) Len() int {
+	return len(idx.positions)
+}
+
+// Lookup returns the set of IDs active at the hour without allocation.
+// The set is the view of the index and is valid until the next modification of the index.
+func (idx *HoursIndex) Lookup(weekDay time.Weekday, hour byte) IndexIDs {
+	if weekDay < 0 || weekDay > time.Saturday || hour > 23 {
+		return IndexIDs{}
+	}
+	return IndexIDs{index: idx, bits: idx.table[hour][weekDay]}
+}
+
+// LookupTime returns the set of IDs active at the hour of the time
+func (idx *HoursIndex) LookupTime(t time.Time) IndexIDs {
+	return idx.Lookup(t.Weekday(), byte(t.Hour()))
+}
+
+// AppendIDs appends IDs active at the hour to dst,
+// doesn't allocate if dst has enough capacity
+func (idx *HoursIndex) AppendIDs(dst []uint32, weekDay time.Weekday, hour byte) []uint32 {
+	return idx.Lookup(weekDay, hour).AppendTo(dst)
+}
+
+func (idx *HoursIndex) clear(pos uint32) {
+	for hour := range idx.table {
+		for day := range idx.table[hour] {
+			idx.table[hour][day].clear(pos)
+		}
+	}
+}
+
+// IndexIDs is the set of IDs of the HoursIndex active at some hour
+type IndexIDs struct {
+	index *HoursIndex
+	bits  bitmap
+}
+
+// Contains returns true if the ID is in the set
+func (s IndexIDs) Contains(id uint32) bool {
+	if s.index == nil {
+		return false
+	}
+	pos, ok := s.index.positions[id]
+	return ok && s.bits.contains(pos)
+}
+
+// Count of the IDs in the set
+func (s IndexIDs) Count() (n int) {
+	for _, word := range s.bits {
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
+
+// AppendTo appends IDs of the set to dst. The order of the IDs is not defined,
+// it is the order of the positions in the index.
+func (s IndexIDs) AppendTo(dst []uint32) []uint32 {
+	for i, word := range s.bits {
+		for word != 0 {
+			dst = append(dst, s.index.ids[i*64+bits.TrailingZeros64(word)])
+			word &= word - 1
+		}
+	}
+	return dst
+}
+
+// Each calls fn for every ID of the set until fn returns false
+func (s IndexIDs) Each(fn func(id uint32) bool) {
+	for i, word := range s.bits {
+		for word != 0 {
+			if !fn(s.index.ids[i*64+bits.TrailingZeros64(word)]) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
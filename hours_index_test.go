@@ -0,0 +1,184 @@
+package hourstable
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestHoursIndex(t *testing.T) {
+	idx := NewHoursIndex()
+	idx.Add(1, nil)
+	idx.Add(2, MustHoursByRanges("Mon-Fri 9-17"))
+	idx.Add(100, MustHoursByRanges("Sat-Sun 10-14; Mon 0-1"))
+	idx.Add(3, make(Hours, 24))
+
+	tests := []struct {
+		name    string
+		weekDay time.Weekday
+		hour    byte
+		ids     []uint32
+	}{
+		{name: "monday morning", weekDay: time.Monday, hour: 0, ids: []uint32{1, 100}},
+		{name: "monday business", weekDay: time.Monday, hour: 9, ids: []uint32{1, 2}},
+		{name: "friday evening", weekDay: time.Friday, hour: 17, ids: []uint32{1}},
+		{name: "sunday noon", weekDay: time.Sunday, hour: 12, ids: []uint32{1, 100}},
+		{name: "invalid hour", weekDay: time.Sunday, hour: 24, ids: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ids := idx.AppendIDs(nil, tt.weekDay, tt.hour); !equalIDs(ids, tt.ids) {
+				t.Errorf("AppendIDs() = %v, expected %v", ids, tt.ids)
+			}
+			if n := idx.Lookup(tt.weekDay, tt.hour).Count(); n != len(tt.ids) {
+				t.Errorf("Lookup().Count() = %d, expected %d", n, len(tt.ids))
+			}
+		})
+	}
+
+	if n := idx.Len(); n != 4 || !idx.Has(3) || idx.Has(4) {
+		t.Errorf("Len() = %d, expected 4 IDs", n)
+	}
+
+	// Update replaces the table and remove drops the ID from every hour
+	if !idx.Update(2, MustHoursByRanges("Fri 17-18")) || idx.Update(4, nil) {
+		t.Error("Update() must change the existing IDs only")
+	}
+	if !idx.Remove(1) || idx.Remove(1) {
+		t.Error("Remove() must remove the existing IDs only")
+	}
+	if ids := idx.AppendIDs(nil, time.Monday, 9); len(ids) != 0 {
+		t.Errorf("AppendIDs() = %v, expected empty", ids)
+	}
+	if ids := idx.AppendIDs(nil, time.Friday, 17); !equalIDs(ids, []uint32{2}) {
+		t.Errorf("AppendIDs() = %v, expected [2]", ids)
+	}
+	if !idx.LookupTime(time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)).Contains(100) {
+		t.Error("LookupTime() must contain 100")
+	}
+	if n := idx.Len(); n != 3 || idx.Has(1) {
+		t.Errorf("Len() = %d, expected 3 IDs", n)
+	}
+}
+
+func TestHoursIndex_Random(t *testing.T) {
+	var (
+		rnd   = rand.New(rand.NewSource(1))
+		idx   = NewHoursIndex()
+		hours = map[uint32]Hours{}
+	)
+	for i := 0; i < 2000; i++ {
+		id := uint32(rnd.Intn(500))
+		switch rnd.Intn(3) {
+		case 0:
+			idx.Remove(id)
+			delete(hours, id)
+		default:
+			h := make(Hours, rnd.Intn(25))
+			for j := range h {
+				h[j] = byte(rnd.Intn(128))
+			}
+			idx.Add(id, h)
+			hours[id] = h
+		}
+	}
+
+	for weekDay := time.Sunday; weekDay <= time.Saturday; weekDay++ {
+		for hour := byte(0); hour < 24; hour++ {
+			set := idx.Lookup(weekDay, hour)
+			for id := uint32(0); id < 500; id++ {
+				h, ok := hours[id]
+				if expected := ok && h.TestHour(weekDay, hour); set.Contains(id) != expected {
+					t.Fatalf("Lookup(%s, %d).Contains(%d) = %v, expected %v", weekDay, hour, id, !expected, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestHoursIndex_LargeIDs(t *testing.T) {
+	idx := NewHoursIndex()
+	idx.Add(4e9, MustHoursByRanges("Mon 9-10"))
+	idx.Add(math.MaxUint32, nil)
+	idx.Add(7, MustHoursByRanges("Mon 9-10"))
+
+	if ids := idx.AppendIDs(nil, time.Monday, 9); !equalIDs(ids, []uint32{4e9, math.MaxUint32, 7}) {
+		t.Errorf("AppendIDs() = %v, expected [4000000000 4294967295 7]", ids)
+	}
+	if set := idx.Lookup(time.Monday, 9); !set.Contains(4e9) || set.Contains(4e9+1) {
+		t.Error("Lookup().Contains() of the large ID")
+	}
+
+	// The positions of the removed IDs are reused by the new IDs
+	idx.Remove(4e9)
+	idx.Add(3e9, MustHoursByRanges("Tue"))
+	if ids := idx.AppendIDs(nil, time.Tuesday, 0); !equalIDs(ids, []uint32{3e9, math.MaxUint32}) {
+		t.Errorf("AppendIDs() = %v, expected [3000000000 4294967295]", ids)
+	}
+	if n := len(idx.ids); n != 3 {
+		t.Errorf("index has %d positions, expected 3", n)
+	}
+	for hour := range idx.table {
+		for day, bits := range idx.table[hour] {
+			if len(bits) > 1 {
+				t.Fatalf("bitmap of %s %d has %d words, expected 1", time.Weekday(day), hour, len(bits))
+			}
+		}
+	}
+}
+
+func TestHoursIndex_Allocs(t *testing.T) {
+	idx := NewHoursIndex()
+	for id := uint32(0); id < 10000; id++ {
+		idx.Add(id, MustHoursByRanges("Mon-Fri 9-17"))
+	}
+	var (
+		now = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		buf = make([]uint32, 0, 10000)
+	)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = idx.LookupTime(now).Contains(42)
+		buf = idx.AppendIDs(buf[:0], time.Monday, 10)
+	})
+	if allocs != 0 || len(buf) != 10000 {
+		t.Errorf("lookup allocs = %v, found %d IDs", allocs, len(buf))
+	}
+}
+
+func Benchmark_HoursIndex(b *testing.B) {
+	var (
+		idx = NewHoursIndex()
+		rnd = rand.New(rand.NewSource(1))
+		now = time.Now()
+	)
+	for id := uint32(0); id < 200000; id++ {
+		h := make(Hours, 24)
+		for j := range h {
+			h[j] = byte(rnd.Intn(128))
+		}
+		idx.Add(id, h)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		buf := make([]uint32, 0, 200000)
+		for pb.Next() {
+			buf = idx.AppendIDs(buf[:0], now.Weekday(), byte(now.Hour()))
+		}
+	})
+}
+
+func equalIDs(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
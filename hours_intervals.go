@@ -0,0 +1,87 @@
+package hourstable
+
+import "time"
+
+// Interval of time [Start, End)
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration of the interval
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Intervals returns merged active intervals of the hours between from and to.
+// Adjacent active hours are coalesced into one interval, including
+// the hours over midnight and over the week end. The first and the last
+// intervals are cut by from and to, the times keep the location of from.
+func (h Hours) Intervals(from, to time.Time) []Interval {
+	var list []Interval
+	h.eachInterval(from, to, func(i Interval) bool {
+		list = append(list, i)
+		return true
+	})
+	return list
+}
+
+// Intervals in the time zone of the table, see Hours.Intervals
+func (z ZonedHours) Intervals(from, to time.Time) []Interval {
+	var list []Interval
+	z.eachInterval(from, to, func(i Interval) bool {
+		list = append(list, i)
+		return true
+	})
+	return list
+}
+
+func (h Hours) eachInterval(from, to time.Time, yield func(Interval) bool) {
+	switch {
+	case !from.Before(to) || h.IsNoActive():
+	case h.IsAllActive():
+		yield(Interval{Start: from, End: to.In(from.Location())})
+	default:
+		walkIntervals(h.TestTime, from, to.In(from.Location()), lookupSteps, yield)
+	}
+}
+
+func (z ZonedHours) eachInterval(from, to time.Time, yield func(Interval) bool) {
+	loc := from.Location()
+	z.Hours.eachInterval(z.Time(from), z.Time(to), func(i Interval) bool {
+		return yield(Interval{Start: i.Start.In(loc), End: i.End.In(loc)})
+	})
+}
+
+// walkIntervals yields active intervals between from and to,
+// the state changes are searched by steps hours at once
+func walkIntervals(test func(time.Time) bool, from, to time.Time, steps int, yield func(Interval) bool) {
+	for cur := from; cur.Before(to); {
+		start, err := nextState(test, cur, true, steps, ErrNeverActive)
+		if err != nil {
+			cur = hourStart(cur).Add(time.Duration(steps) * time.Hour)
+			continue
+		}
+		if !start.Before(to) {
+			return
+		}
+		end := start
+		for {
+			next, err := nextState(test, end, false, steps, ErrAlwaysActive)
+			if err == nil {
+				end = next
+				break
+			}
+			if end = hourStart(end).Add(time.Duration(steps) * time.Hour); !end.Before(to) {
+				break
+			}
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !yield(Interval{Start: start, End: end}) {
+			return
+		}
+		cur = end
+	}
+}
@@ -0,0 +1,140 @@
+package hourstable
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHours_Intervals(t *testing.T) {
+	day := func(d, h int) time.Time {
+		return time.Date(2026, 1, d, h, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		hours    Hours
+		from, to time.Time
+		expected []Interval
+	}{
+		{
+			name:     "all active",
+			hours:    nil,
+			from:     day(12, 5),
+			to:       day(14, 5),
+			expected: []Interval{{Start: day(12, 5), End: day(14, 5)}},
+		},
+		{
+			name:  "never active",
+			hours: make(Hours, 24),
+			from:  day(12, 5),
+			to:    day(14, 5),
+		},
+		{
+			name:  "empty range",
+			hours: nil,
+			from:  day(14, 5),
+			to:    day(12, 5),
+		},
+		{
+			name:  "business days cut by range",
+			hours: MustHoursByRanges("Mon-Fri 9-17"),
+			from:  day(12, 12), // Monday
+			to:    day(14, 10),
+			expected: []Interval{
+				{Start: day(12, 12), End: day(12, 17)},
+				{Start: day(13, 9), End: day(13, 17)},
+				{Start: day(14, 9), End: day(14, 10)},
+			},
+		},
+		{
+			name:  "coalesced over midnight and week end",
+			hours: MustHoursByRanges("Fri 22-24; Sat; Sun 0-3; Mon 5-6"),
+			from:  day(16, 0), // Friday
+			to:    day(20, 0),
+			expected: []Interval{
+				{Start: day(16, 22), End: day(18, 3)},
+				{Start: day(19, 5), End: day(19, 6)},
+			},
+		},
+		{
+			name:  "over the week wrap",
+			hours: MustHoursByRanges("Sat 20-02"),
+			from:  day(17, 21), // Saturday
+			to:    day(25, 0),
+			expected: []Interval{
+				{Start: day(17, 21), End: day(18, 2)},
+				{Start: day(24, 20), End: day(25, 0)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := tt.hours.Intervals(tt.from, tt.to)
+			if len(list) != len(tt.expected) {
+				t.Fatalf("Intervals() = %v, expected %v", list, tt.expected)
+			}
+			for i, interval := range list {
+				if !interval.Start.Equal(tt.expected[i].Start) || !interval.End.Equal(tt.expected[i].End) {
+					t.Errorf("Intervals()[%d] = %v, expected %v", i, interval, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestZonedHours_Intervals(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	hours := MustHoursByRanges("Sun 1-4").In(berlin)
+
+	// The 25 hours day of the DST end keeps the wall clock hours
+	list := hours.Intervals(
+		time.Date(2026, 10, 1, 0, 0, 0, 0, berlin),
+		time.Date(2026, 10, 31, 0, 0, 0, 0, berlin),
+	)
+	if len(list) != 4 {
+		t.Fatalf("Intervals() = %v, expected 4 intervals", list)
+	}
+	if d := list[3].Duration(); d != 4*time.Hour {
+		t.Errorf("Intervals()[3] duration = %v, expected 4h: %v", d, list[3])
+	}
+	if d := list[0].Duration(); d != 3*time.Hour {
+		t.Errorf("Intervals()[0] duration = %v, expected 3h: %v", d, list[0])
+	}
+}
+
+func TestHours_IntervalsDST(t *testing.T) {
+	var (
+		berlin   = mustLoadLocation(t, "Europe/Berlin")
+		lordHowe = mustLoadLocation(t, "Australia/Lord_Howe")
+		lunch    = MustHoursByRanges("Mon-Sat; Sun 0-12,13-24")
+		night    = MustHoursByRanges("Sun 1-4; Mon-Fri 9-17")
+	)
+
+	tests := []struct {
+		name     string
+		hours    Hours
+		from, to time.Time
+	}{
+		{name: "half hour spring forward", hours: lunch, from: time.Date(2026, 10, 3, 0, 0, 0, 0, lordHowe), to: time.Date(2026, 10, 6, 0, 0, 0, 0, lordHowe)},
+		{name: "half hour fall back", hours: lunch, from: time.Date(2026, 4, 4, 0, 0, 0, 0, lordHowe), to: time.Date(2026, 4, 7, 0, 0, 0, 0, lordHowe)},
+		{name: "cut inside the shifted hour", hours: lunch, from: time.Date(2026, 10, 4, 2, 40, 0, 0, lordHowe), to: time.Date(2026, 10, 4, 12, 20, 0, 0, lordHowe)},
+		{name: "spring forward", hours: night, from: time.Date(2026, 3, 27, 0, 0, 0, 0, berlin), to: time.Date(2026, 3, 31, 0, 0, 0, 0, berlin)},
+		{name: "fall back", hours: night, from: time.Date(2026, 10, 23, 0, 0, 0, 0, berlin), to: time.Date(2026, 10, 27, 0, 0, 0, 0, berlin)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var total time.Duration
+			for _, interval := range tt.hours.Intervals(tt.from, tt.to) {
+				if interval.Start.Minute() != 0 && !interval.Start.Equal(tt.from) || interval.End.Minute() != 0 && !interval.End.Equal(tt.to) {
+					t.Errorf("Intervals() = %v, expected the bounds of the wall clock hours", interval)
+				}
+				total += interval.Duration()
+			}
+			if expected := tt.hours.ActiveDuration(tt.from, tt.to); total != expected {
+				t.Errorf("Intervals() total = %v, expected ActiveDuration() %v", total, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//go:build go1.23
+
+package hourstable
+
+import (
+	"iter"
+	"time"
+)
+
+// IntervalsSeq returns the sequence of merged active intervals
+// of the hours between from and to, see Hours.Intervals
+func (h Hours) IntervalsSeq(from, to time.Time) iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		h.eachInterval(from, to, yield)
+	}
+}
+
+// IntervalsSeq in the time zone of the table, see Hours.Intervals
+func (z ZonedHours) IntervalsSeq(from, to time.Time) iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		z.eachInterval(from, to, yield)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package hourstable
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHours_IntervalsSeq(t *testing.T) {
+	var (
+		hours = MustHoursByRanges("Mon-Fri 9-17")
+		from  = time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC) // Monday
+		to    = time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC)
+		list  []Interval
+	)
+	for interval := range hours.IntervalsSeq(from, to) {
+		list = append(list, interval)
+		if len(list) == 3 {
+			break
+		}
+	}
+	if expected := hours.Intervals(from, to)[:3]; !reflect.DeepEqual(list, expected) {
+		t.Errorf("IntervalsSeq() = %v, expected %v", list, expected)
+	}
+}
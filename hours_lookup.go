@@ -0,0 +1,143 @@
+package hourstable
+
+import (
+	"errors"
+	"time"
+)
+
+// Lookup errors returned when the searched state never happens
+var (
+	ErrNeverActive  = errors.New("[hours] the hours are never active")
+	ErrAlwaysActive = errors.New("[hours] the hours are always active")
+)
+
+// Two weeks of hours, because the only active hour of the week
+// could be skipped by DST transition
+const lookupSteps = 2 * 7 * 24
+
+// NextActive returns t if the hours are active at t, otherwise the start
+// of the next active hour. The result keeps the location of t.
+// ErrNeverActive is returned if the hours have no active hours.
+func (h Hours) NextActive(t time.Time) (time.Time, error) {
+	if h.IsNoActive() {
+		return time.Time{}, ErrNeverActive
+	}
+	return nextState(h.TestTime, t, true, lookupSteps, ErrNeverActive)
+}
+
+// NextInactive returns t if the hours are inactive at t, otherwise the end
+// of the current active period. The result keeps the location of t.
+// ErrAlwaysActive is returned if all hours are active.
+func (h Hours) NextInactive(t time.Time) (time.Time, error) {
+	if h.IsAllActive() {
+		return time.Time{}, ErrAlwaysActive
+	}
+	return nextState(h.TestTime, t, false, lookupSteps, ErrAlwaysActive)
+}
+
+// PrevActive returns t if the hours are active at t, otherwise the end
+// of the previous active period. The result keeps the location of t.
+// ErrNeverActive is returned if the hours have no active hours.
+func (h Hours) PrevActive(t time.Time) (time.Time, error) {
+	if h.IsNoActive() {
+		return time.Time{}, ErrNeverActive
+	}
+	return prevState(h.TestTime, t, true, lookupSteps, ErrNeverActive)
+}
+
+// PrevInactive returns t if the hours are inactive at t, otherwise the start
+// of the current active period. The result keeps the location of t.
+// ErrAlwaysActive is returned if all hours are active.
+func (h Hours) PrevInactive(t time.Time) (time.Time, error) {
+	if h.IsAllActive() {
+		return time.Time{}, ErrAlwaysActive
+	}
+	return prevState(h.TestTime, t, false, lookupSteps, ErrAlwaysActive)
+}
+
+// NextActive in the time zone of the table, see Hours.NextActive
+func (z ZonedHours) NextActive(t time.Time) (time.Time, error) {
+	return inLocation(t)(z.Hours.NextActive(z.Time(t)))
+}
+
+// NextInactive in the time zone of the table, see Hours.NextInactive
+func (z ZonedHours) NextInactive(t time.Time) (time.Time, error) {
+	return inLocation(t)(z.Hours.NextInactive(z.Time(t)))
+}
+
+// PrevActive in the time zone of the table, see Hours.PrevActive
+func (z ZonedHours) PrevActive(t time.Time) (time.Time, error) {
+	return inLocation(t)(z.Hours.PrevActive(z.Time(t)))
+}
+
+// PrevInactive in the time zone of the table, see Hours.PrevInactive
+func (z ZonedHours) PrevInactive(t time.Time) (time.Time, error) {
+	return inLocation(t)(z.Hours.PrevInactive(z.Time(t)))
+}
+
+// inLocation returns converter of the lookup result into the location of t
+func inLocation(t time.Time) func(time.Time, error) (time.Time, error) {
+	return func(res time.Time, err error) (time.Time, error) {
+		if err != nil {
+			return res, err
+		}
+		return res.In(t.Location()), nil
+	}
+}
+
+// nextState walks hour by hour from t until the state of the hour is equal to the state.
+// The limit of steps protects from the infinite loops, notFound error is returned after it.
+func nextState(test func(time.Time) bool, t time.Time, state bool, steps int, notFound error) (time.Time, error) {
+	if test(t) == state {
+		return t, nil
+	}
+	for hour := t; steps > 0; steps-- {
+		if hour = nextHour(hour); test(hour) == state {
+			return hour, nil
+		}
+	}
+	return time.Time{}, notFound
+}
+
+// prevState walks hour by hour back from t until the state of the hour
+// is equal to the state and returns the end of that hour.
+func prevState(test func(time.Time) bool, t time.Time, state bool, steps int, notFound error) (time.Time, error) {
+	if test(t) == state {
+		return t, nil
+	}
+	for hour := hourStart(t); steps > 0; steps-- {
+		prev := hourStart(hour.Add(-1))
+		if test(prev) == state {
+			return hour, nil
+		}
+		hour = prev
+	}
+	return time.Time{}, notFound
+}
+
+// hourStart returns the beginning of the wall clock hour of t.
+// The hour could begin by the zone transition, e.g. at 02:30 after the half hour DST shift.
+func hourStart(t time.Time) time.Time {
+	start := t.Add(-sinceHourStart(t))
+	if zoneStart, _ := t.ZoneBounds(); zoneStart.After(start) {
+		return zoneStart
+	}
+	return start
+}
+
+// nextHour returns the beginning of the wall clock hour after t.
+// Every step is aligned by the wall clock, so the walk keeps the whole hours
+// after DST shifts which are not a whole hour.
+func nextHour(t time.Time) time.Time {
+	next := t.Add(time.Hour - sinceHourStart(t))
+	if _, zoneEnd := t.ZoneBounds(); !zoneEnd.IsZero() && zoneEnd.Before(next) {
+		return zoneEnd
+	}
+	return next
+}
+
+func sinceHourStart(t time.Time) time.Duration {
+	return time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+}
@@ -0,0 +1,225 @@
+package hourstable
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHours_Lookup(t *testing.T) {
+	// Monday-Friday 9AM-5PM
+	business := MustHoursByRanges("Mon-Fri 9-17")
+	local := time.FixedZone("UTC+3", 3*60*60)
+
+	tests := []struct {
+		name     string
+		hours    Hours
+		lookup   func(Hours, time.Time) (time.Time, error)
+		from     time.Time
+		expected time.Time
+		err      error
+	}{
+		{
+			name:     "next active: active now",
+			hours:    business,
+			lookup:   Hours.NextActive,
+			from:     time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC), // Monday
+			expected: time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "next active: next morning",
+			hours:    business,
+			lookup:   Hours.NextActive,
+			from:     time.Date(2026, 1, 12, 17, 30, 0, 0, time.UTC), // Monday
+			expected: time.Date(2026, 1, 13, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "next active: over the week end",
+			hours:    business,
+			lookup:   Hours.NextActive,
+			from:     time.Date(2026, 1, 16, 20, 0, 0, 0, local), // Friday
+			expected: time.Date(2026, 1, 19, 9, 0, 0, 0, local),
+		},
+		{
+			name:     "next active: all active",
+			hours:    nil,
+			lookup:   Hours.NextActive,
+			from:     time.Date(2026, 1, 16, 20, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, 1, 16, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "next active: never active",
+			hours:  make(Hours, 24),
+			lookup: Hours.NextActive,
+			from:   time.Date(2026, 1, 16, 20, 0, 0, 0, time.UTC),
+			err:    ErrNeverActive,
+		},
+		{
+			name:     "next inactive: end of the day",
+			hours:    business,
+			lookup:   Hours.NextInactive,
+			from:     time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC),
+			expected: time.Date(2026, 1, 12, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "next inactive: all active",
+			hours:  nil,
+			lookup: Hours.NextInactive,
+			from:   time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC),
+			err:    ErrAlwaysActive,
+		},
+		{
+			name:     "next inactive: never active",
+			hours:    make(Hours, 24),
+			lookup:   Hours.NextInactive,
+			from:     time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC),
+			expected: time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "next inactive: over midnight and week wrap",
+			hours:    MustHoursByRanges("Sat 20-24; Sun 0-2"),
+			lookup:   Hours.NextInactive,
+			from:     time.Date(2026, 1, 17, 21, 15, 0, 0, time.UTC), // Saturday
+			expected: time.Date(2026, 1, 18, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "prev active: end of the previous day",
+			hours:    business,
+			lookup:   Hours.PrevActive,
+			from:     time.Date(2026, 1, 13, 8, 30, 0, 0, time.UTC), // Tuesday
+			expected: time.Date(2026, 1, 12, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "prev active: active now",
+			hours:    business,
+			lookup:   Hours.PrevActive,
+			from:     time.Date(2026, 1, 13, 9, 30, 0, 0, time.UTC),
+			expected: time.Date(2026, 1, 13, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "prev active: never active",
+			hours:  make(Hours, 24),
+			lookup: Hours.PrevActive,
+			from:   time.Date(2026, 1, 13, 9, 30, 0, 0, time.UTC),
+			err:    ErrNeverActive,
+		},
+		{
+			name:     "prev inactive: start of the period",
+			hours:    business,
+			lookup:   Hours.PrevInactive,
+			from:     time.Date(2026, 1, 13, 12, 30, 0, 0, time.UTC),
+			expected: time.Date(2026, 1, 13, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "prev inactive: all active",
+			hours:  nil,
+			lookup: Hours.PrevInactive,
+			from:   time.Date(2026, 1, 13, 12, 30, 0, 0, time.UTC),
+			err:    ErrAlwaysActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.lookup(tt.hours, tt.from)
+			if err != tt.err {
+				t.Fatalf("lookup error = %v, expected %v", err, tt.err)
+			}
+			if !result.Equal(tt.expected) {
+				t.Errorf("lookup = %v, expected %v", result, tt.expected)
+			}
+			if err == nil && result.Location() != tt.from.Location() {
+				t.Errorf("lookup location = %v, expected %v", result.Location(), tt.from.Location())
+			}
+		})
+	}
+}
+
+func TestZonedHours_Lookup(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	night := MustHoursByRanges("Sun 2-3").In(berlin)
+
+	// 02:00 doesn't exist on 2026-03-29, so the next one is a week later
+	next, err := night.NextActive(time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NextActive() error = %v", err)
+	}
+	if expected := time.Date(2026, 4, 5, 2, 0, 0, 0, berlin); !next.Equal(expected) {
+		t.Errorf("NextActive() = %v, expected %v", next, expected)
+	}
+	if next.Location() != time.UTC {
+		t.Errorf("NextActive() location = %v, expected UTC", next.Location())
+	}
+
+	// 02:00 happens twice on 2026-10-25, the period takes two hours
+	end, err := night.NextInactive(time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NextInactive() error = %v", err)
+	}
+	if expected := time.Date(2026, 10, 25, 2, 0, 0, 0, time.UTC); !end.Equal(expected) {
+		t.Errorf("NextInactive() = %v, expected %v", end, expected)
+	}
+
+	start, err := night.PrevInactive(time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("PrevInactive() error = %v", err)
+	}
+	if expected := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC); !start.Equal(expected) {
+		t.Errorf("PrevInactive() = %v, expected %v", start, expected)
+	}
+}
+
+func TestHours_LookupHalfHourDST(t *testing.T) {
+	// Lord Howe moves the clock by 30 minutes: 02:00 -> 02:30 on 2026-10-04, 02:00 -> 01:30 on 2026-04-05
+	lordHowe := mustLoadLocation(t, "Australia/Lord_Howe")
+	lunch := MustHoursByRanges("Mon-Sat; Sun 0-12,13-24")
+
+	tests := []struct {
+		name     string
+		lookup   func(Hours, time.Time) (time.Time, error)
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "next inactive after spring forward",
+			lookup:   Hours.NextInactive,
+			from:     time.Date(2026, 10, 3, 0, 0, 0, 0, lordHowe),
+			expected: time.Date(2026, 10, 4, 12, 0, 0, 0, lordHowe),
+		},
+		{
+			name:     "next active after spring forward",
+			lookup:   Hours.NextActive,
+			from:     time.Date(2026, 10, 4, 12, 10, 0, 0, lordHowe),
+			expected: time.Date(2026, 10, 4, 13, 0, 0, 0, lordHowe),
+		},
+		{
+			name:     "prev active over spring forward",
+			lookup:   Hours.PrevActive,
+			from:     time.Date(2026, 10, 4, 12, 40, 0, 0, lordHowe),
+			expected: time.Date(2026, 10, 4, 12, 0, 0, 0, lordHowe),
+		},
+		{
+			name:     "next inactive after fall back",
+			lookup:   Hours.NextInactive,
+			from:     time.Date(2026, 4, 4, 0, 0, 0, 0, lordHowe),
+			expected: time.Date(2026, 4, 5, 12, 0, 0, 0, lordHowe),
+		},
+		{
+			name:     "prev inactive over fall back",
+			lookup:   Hours.PrevInactive,
+			from:     time.Date(2026, 4, 5, 11, 45, 0, 0, lordHowe),
+			expected: time.Date(2026, 3, 29, 13, 0, 0, 0, lordHowe),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.lookup(lunch, tt.from)
+			if err != nil {
+				t.Fatalf("lookup error = %v", err)
+			}
+			if !result.Equal(tt.expected) {
+				t.Errorf("lookup = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
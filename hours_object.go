@@ -17,13 +17,13 @@ import (
 
 //easyjson:json
 type timetableJSON struct {
-	Monday    string `json:"mon,omitempty" yaml:"mon,omitempty"`
-	Tuesday   string `json:"tue,omitempty" yaml:"tue,omitempty"`
-	Wednesday string `json:"wed,omitempty" yaml:"wed,omitempty"`
-	Thursday  string `json:"thu,omitempty" yaml:"thu,omitempty"`
-	Friday    string `json:"fri,omitempty" yaml:"fri,omitempty"`
-	Saturday  string `json:"sat,omitempty" yaml:"sat,omitempty"`
-	Sunday    string `json:"sun,omitempty" yaml:"sun,omitempty"`
+	Monday    string `json:"mon,omitempty" yaml:"mon,omitempty" xml:"mon,omitempty"`
+	Tuesday   string `json:"tue,omitempty" yaml:"tue,omitempty" xml:"tue,omitempty"`
+	Wednesday string `json:"wed,omitempty" yaml:"wed,omitempty" xml:"wed,omitempty"`
+	Thursday  string `json:"thu,omitempty" yaml:"thu,omitempty" xml:"thu,omitempty"`
+	Friday    string `json:"fri,omitempty" yaml:"fri,omitempty" xml:"fri,omitempty"`
+	Saturday  string `json:"sat,omitempty" yaml:"sat,omitempty" xml:"sat,omitempty"`
+	Sunday    string `json:"sun,omitempty" yaml:"sun,omitempty" xml:"sun,omitempty"`
 }
 
 func (tt *timetableJSON) ToHours() Hours {
@@ -0,0 +1,393 @@
+package hourstable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// OSMUnsupportedError is returned by HoursByOSM when the opening_hours value
+// contains constructs which can't be represented by the weekly table.
+// The hours are still returned, the error lists everything which was dropped.
+type OSMUnsupportedError struct {
+	Dropped []string
+}
+
+// Error implementation of error interface
+func (e *OSMUnsupportedError) Error() string {
+	return "[hours] unsupported opening_hours constructs dropped: " + strings.Join(e.Dropped, "; ")
+}
+
+var osmWeekDays = map[string]time.Weekday{
+	"su": time.Sunday, "mo": time.Monday, "tu": time.Tuesday, "we": time.Wednesday,
+	"th": time.Thursday, "fr": time.Friday, "sa": time.Saturday,
+}
+
+// Words of the opening_hours syntax which have no weekly representation
+var osmUnsupportedWords = map[string]bool{
+	"jan": true, "feb": true, "mar": true, "apr": true, "may": true, "jun": true,
+	"jul": true, "aug": true, "sep": true, "oct": true, "nov": true, "dec": true,
+	"week": true, "easter": true, "unknown": true,
+	"sunrise": true, "sunset": true, "dawn": true, "dusk": true,
+}
+
+// HoursByOSM parses the weekly subset of the OpenStreetMap opening_hours syntax
+//
+//	Mo-Fr 08:00-20:00; Sa 09:00-14:00; Su off
+//
+// Rules separated by ';' replace the hours of the days selected before,
+// rules separated by ',' extend them. Minutes must be ":00".
+//
+// Public and school holidays, months, dates, week numbers, years, variable times,
+// fallback rules and comments are dropped, in this case the hours are returned
+// together with *OSMUnsupportedError which lists the dropped parts.
+// Syntax errors are reported as *ParseError.
+func HoursByOSM(s string) (Hours, error) {
+	p := osmParser{input: []rune(s)}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) == 0 {
+		return nil, &ParseError{Column: 1, Reason: "empty opening_hours"}
+	}
+
+	// Every day keeps own hours and the hours after midnight (up to 48h)
+	var week [7]uint64
+	if err := p.parse(&week); err != nil {
+		return nil, err
+	}
+
+	h := make(Hours, 24)
+	for day, row := range week {
+		for hour := 0; hour < 48; hour++ {
+			if row&(1<<hour) != 0 {
+				h[hour%24] |= byte(0x01) << byte((day+hour/24)%7)
+			}
+		}
+	}
+	if h.IsAllActive() {
+		h = nil
+	}
+	if len(p.dropped) > 0 {
+		return h, &OSMUnsupportedError{Dropped: p.dropped}
+	}
+	return h, nil
+}
+
+// OSMString returns the hours table in the OpenStreetMap opening_hours syntax
+func (h Hours) OSMString() string {
+	return osmFormat.format(h)
+}
+
+var osmFormat = rangeFormat{
+	days:      [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+	allActive: "24/7",
+	noActive:  "off",
+	fullDay:   "00:00-24:00",
+	hoursFmt: func(from, to int) string {
+		return fmt.Sprintf("%02d:00-%02d:00", from, to)
+	},
+}
+
+type osmTokenKind int
+
+const (
+	osmWord osmTokenKind = iota
+	osmNumber
+	osmTime
+	osmComment
+	osmPunct
+)
+
+type osmToken struct {
+	kind     osmTokenKind
+	text     string
+	pos, end int
+}
+
+type osmParser struct {
+	input   []rune
+	tokens  []osmToken
+	i       int
+	dropped []string
+}
+
+func (p *osmParser) tokenize() error {
+	for pos := 0; pos < len(p.input); {
+		c, start := p.input[pos], pos
+		switch {
+		case unicode.IsSpace(c):
+			pos++
+			continue
+		case unicode.IsLetter(c):
+			for pos < len(p.input) && unicode.IsLetter(p.input[pos]) {
+				pos++
+			}
+			p.tokens = append(p.tokens, osmToken{kind: osmWord, text: string(p.input[start:pos]), pos: start, end: pos})
+		case unicode.IsDigit(c):
+			for pos < len(p.input) && unicode.IsDigit(p.input[pos]) {
+				pos++
+			}
+			kind := osmNumber
+			if pos+2 < len(p.input) && p.input[pos] == ':' && unicode.IsDigit(p.input[pos+1]) && unicode.IsDigit(p.input[pos+2]) {
+				kind, pos = osmTime, pos+3
+			}
+			p.tokens = append(p.tokens, osmToken{kind: kind, text: string(p.input[start:pos]), pos: start, end: pos})
+		case c == '"':
+			pos++
+			for pos < len(p.input) && p.input[pos] != '"' {
+				pos++
+			}
+			if pos >= len(p.input) {
+				return &ParseError{Column: start + 1, Reason: "unterminated comment"}
+			}
+			pos++
+			p.tokens = append(p.tokens, osmToken{kind: osmComment, text: string(p.input[start:pos]), pos: start, end: pos})
+		case c == '|' && pos+1 < len(p.input) && p.input[pos+1] == '|':
+			pos += 2
+			p.tokens = append(p.tokens, osmToken{kind: osmPunct, text: "||", pos: start, end: pos})
+		default:
+			pos++
+			p.tokens = append(p.tokens, osmToken{kind: osmPunct, text: string(c), pos: start, end: pos})
+		}
+	}
+	return nil
+}
+
+func (p *osmParser) parse(week *[7]uint64) error {
+	additional := false
+	for {
+		if p.i >= len(p.tokens) || p.isPunct(";") || p.isPunct("||") {
+			return p.errorf("empty rule")
+		}
+		if err := p.parseRule(week, additional); err != nil {
+			return err
+		}
+		// Fallback rules are applied only if nothing else matched
+		for p.isPunct("||") {
+			if p.i++; p.i >= len(p.tokens) || p.isPunct(";") || p.isPunct("||") {
+				return p.errorf("empty rule")
+			}
+			p.dropRule(p.i)
+		}
+		if p.i >= len(p.tokens) {
+			return nil
+		}
+		switch tok := p.tokens[p.i]; tok.text {
+		case ";":
+			additional = false
+		case ",":
+			additional = true
+		default:
+			return p.errorf("unexpected %q, expected ';'", tok.text)
+		}
+		// Trailing separator is allowed
+		if p.i++; p.i >= len(p.tokens) {
+			return nil
+		}
+	}
+}
+
+func (p *osmParser) parseRule(week *[7]uint64, additional bool) error {
+	var (
+		start   = p.i
+		dropped = len(p.dropped)
+		days    byte
+		hours   uint64
+		off     bool
+		hasDay  bool
+	)
+	drop := func() error {
+		p.dropped = p.dropped[:dropped]
+		p.dropRule(start)
+		return nil
+	}
+
+	if p.isNumber("24") && p.i+2 < len(p.tokens) && p.tokens[p.i+1].text == "/" && p.tokens[p.i+2].text == "7" {
+		p.i += 3
+		days, hours = daysBitMask, 1<<24-1
+	} else {
+		// Week days selector
+		for p.i < len(p.tokens) && p.tokens[p.i].kind == osmWord {
+			word := strings.ToLower(p.tokens[p.i].text)
+			if word == "ph" || word == "sh" {
+				p.dropped = append(p.dropped, p.tokens[p.i].text)
+				p.i++
+				hasDay = true
+			} else if day, ok := osmWeekDays[word]; ok {
+				p.i++
+				to := day
+				if p.isPunct("-") {
+					p.i++
+					if to, ok = p.weekDay(); !ok {
+						return p.errorf("expected day of week")
+					}
+					p.i++
+				}
+				for d := day; ; d = (d + 1) % 7 {
+					days |= byte(0x01) << byte(d)
+					if d == to {
+						break
+					}
+				}
+				hasDay = true
+			} else {
+				break
+			}
+			if p.isPunct("[") || p.isPunct("+") {
+				return drop()
+			}
+			if !p.isPunct(",") || !p.isWordAt(p.i+1) {
+				break
+			}
+			p.i++
+		}
+		if hasDay && days == 0 {
+			// Only holidays are selected
+			return drop()
+		}
+		if !hasDay {
+			days = daysBitMask
+		}
+
+		// Time selector
+		for p.i < len(p.tokens) && p.tokens[p.i].kind == osmTime {
+			fromTok := p.i
+			from, fromOK, err := p.time()
+			if err != nil {
+				return err
+			}
+			if p.isPunct("+") {
+				return drop()
+			}
+			if !p.isPunct("-") {
+				return p.errorf("expected '-' of the time range")
+			}
+			p.i++
+			if p.i >= len(p.tokens) || p.tokens[p.i].kind != osmTime {
+				if p.i < len(p.tokens) && p.tokens[p.i].kind == osmWord {
+					return drop()
+				}
+				return p.errorf("expected time")
+			}
+			to, toOK, err := p.time()
+			if err != nil {
+				return err
+			}
+			if !fromOK || !toOK || p.isPunct("+") {
+				return drop()
+			}
+			if from >= 24 {
+				p.i = fromTok
+				return p.errorf("time range can't start at %02d:00", from)
+			}
+			if to <= from {
+				to += 24
+			}
+			for hour := from; hour < to && hour < 48; hour++ {
+				hours |= 1 << hour
+			}
+			if !p.isPunct(",") || p.i+1 >= len(p.tokens) || p.tokens[p.i+1].kind != osmTime {
+				break
+			}
+			p.i++
+		}
+
+		// Rule modifier
+		if p.i < len(p.tokens) && p.tokens[p.i].kind == osmWord {
+			switch strings.ToLower(p.tokens[p.i].text) {
+			case "open":
+				p.i++
+			case "off", "closed":
+				p.i++
+				off = true
+			}
+		}
+		if p.i < len(p.tokens) && p.tokens[p.i].kind == osmComment {
+			p.dropped = append(p.dropped, p.tokens[p.i].text)
+			p.i++
+		}
+		if p.i < len(p.tokens) && !p.isPunct(";") && !p.isPunct(",") && !p.isPunct("||") {
+			if tok := p.tokens[p.i]; tok.kind == osmNumber || tok.kind == osmComment ||
+				(tok.kind == osmWord && osmUnsupportedWords[strings.ToLower(tok.text)]) {
+				return drop()
+			}
+			return p.errorf("unexpected %q", p.tokens[p.i].text)
+		}
+		if p.i == start {
+			return p.errorf("expected rule")
+		}
+		if hours == 0 && !off {
+			hours = 1<<24 - 1
+		}
+	}
+
+	for day := time.Weekday(0); day < 7; day++ {
+		if days&(byte(0x01)<<byte(day)) == 0 {
+			continue
+		}
+		switch {
+		case off && hours == 0:
+			week[day] = 0
+		case off:
+			week[day] &= ^hours
+		case additional:
+			week[day] |= hours
+		default:
+			week[day] = hours
+		}
+	}
+	return nil
+}
+
+// dropRule skips the rest of the rule which started at token and marks it as dropped
+func (p *osmParser) dropRule(start int) {
+	for p.i < len(p.tokens) && !p.isPunct(";") && !p.isPunct("||") {
+		p.i++
+	}
+	end := p.tokens[p.i-1].end
+	p.dropped = append(p.dropped, string(p.input[p.tokens[start].pos:end]))
+}
+
+// time returns the hour of the time token, ok is false if minutes are not zero
+func (p *osmParser) time() (hour int, ok bool, err error) {
+	tok := p.tokens[p.i]
+	parts := strings.SplitN(tok.text, ":", 2)
+	hour, _ = strconv.Atoi(parts[0])
+	minutes, _ := strconv.Atoi(parts[1])
+	if hour > 48 || minutes > 59 {
+		return 0, false, p.errorf("invalid time %q", tok.text)
+	}
+	p.i++
+	return hour, minutes == 0, nil
+}
+
+func (p *osmParser) weekDay() (time.Weekday, bool) {
+	if p.i >= len(p.tokens) || p.tokens[p.i].kind != osmWord {
+		return 0, false
+	}
+	day, ok := osmWeekDays[strings.ToLower(p.tokens[p.i].text)]
+	return day, ok
+}
+
+func (p *osmParser) isPunct(text string) bool {
+	return p.i < len(p.tokens) && p.tokens[p.i].kind == osmPunct && p.tokens[p.i].text == text
+}
+
+func (p *osmParser) isNumber(text string) bool {
+	return p.i < len(p.tokens) && p.tokens[p.i].kind == osmNumber && p.tokens[p.i].text == text
+}
+
+func (p *osmParser) isWordAt(i int) bool {
+	return i < len(p.tokens) && p.tokens[i].kind == osmWord
+}
+
+func (p *osmParser) errorf(format string, args ...any) error {
+	column := len(p.input) + 1
+	if p.i < len(p.tokens) {
+		column = p.tokens[p.i].pos + 1
+	}
+	return &ParseError{Column: column, Reason: fmt.Sprintf(format, args...)}
+}
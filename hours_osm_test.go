@@ -0,0 +1,193 @@
+package hourstable
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHoursByOSM(t *testing.T) {
+	shop := func() Hours {
+		h := make(Hours, 24)
+		for day := time.Monday; day <= time.Friday; day++ {
+			for hour := 8; hour < 20; hour++ {
+				h.SetHour(day, byte(hour), true)
+			}
+		}
+		for hour := 9; hour < 14; hour++ {
+			h.SetHour(time.Saturday, byte(hour), true)
+		}
+		return h
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected Hours
+		dropped  []string
+	}{
+		{
+			name:     "all active",
+			input:    "24/7",
+			expected: nil,
+		},
+		{
+			name:     "closed",
+			input:    "off",
+			expected: make(Hours, 24),
+		},
+		{
+			name:     "shop",
+			input:    "Mo-Fr 08:00-20:00; Sa 09:00-14:00",
+			expected: shop(),
+		},
+		{
+			name:     "public holidays",
+			input:    "Mo-Fr 08:00-20:00; Sa 09:00-14:00; PH off",
+			expected: shop(),
+			dropped:  []string{"PH off"},
+		},
+		{
+			name:     "holidays in the list of days",
+			input:    "Mo-Fr,PH 08:00-20:00; Sa 09:00-14:00",
+			expected: shop(),
+			dropped:  []string{"PH"},
+		},
+		{
+			name:     "override of the days",
+			input:    "Mo-Fr 08:00-20:00; We 10:00-12:00",
+			expected: MustHoursByRanges("Mon-Tue,Thu-Fri 8-20; Wed 10-12"),
+		},
+		{
+			name:  "additional rule and off",
+			input: "Mo-Fr 08:00-20:00, Sa 09:00-14:00; We off",
+			expected: func() Hours {
+				h := shop()
+				for hour := 0; hour < 24; hour++ {
+					h.SetHour(time.Wednesday, byte(hour), false)
+				}
+				return h
+			}(),
+		},
+		{
+			name:  "overnight keeps next day",
+			input: "Fr 22:00-02:00; Sa 10:00-12:00",
+			expected: func() Hours {
+				h := make(Hours, 24)
+				h.SetHour(time.Friday, 22, true)
+				h.SetHour(time.Friday, 23, true)
+				h.SetHour(time.Saturday, 0, true)
+				h.SetHour(time.Saturday, 1, true)
+				h.SetHour(time.Saturday, 10, true)
+				h.SetHour(time.Saturday, 11, true)
+				return h
+			}(),
+		},
+		{
+			name:     "months, weeks and comments",
+			input:    `Mo-Fr 08:00-20:00 "summer"; Dec 25 off; week 01-10 Sa 10:00-12:00; Sa 09:00-14:00 || "by appointment"`,
+			expected: shop(),
+			dropped:  []string{`"summer"`, "Dec 25 off", "week 01-10 Sa 10:00-12:00", `"by appointment"`},
+		},
+		{
+			name:  "variable times and minutes",
+			input: "Mo 08:00-sunset; Tu 08:30-12:00; We 10:00+; Su[1] 10:00-12:00; Th 10:00-12:00",
+			expected: func() Hours {
+				h := make(Hours, 24)
+				h.SetHour(time.Thursday, 10, true)
+				h.SetHour(time.Thursday, 11, true)
+				return h
+			}(),
+			dropped: []string{"Mo 08:00-sunset", "Tu 08:30-12:00", "We 10:00+", "Su[1] 10:00-12:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := HoursByOSM(tt.input)
+			var uerr *OSMUnsupportedError
+			if err != nil && !errors.As(err, &uerr) {
+				t.Fatalf("HoursByOSM() error = %v", err)
+			}
+			if uerr != nil && !reflect.DeepEqual(uerr.Dropped, tt.dropped) {
+				t.Errorf("dropped = %q, expected %q", uerr.Dropped, tt.dropped)
+			}
+			if uerr == nil && tt.dropped != nil {
+				t.Errorf("expected *OSMUnsupportedError with %q", tt.dropped)
+			}
+			if !h.Equal(tt.expected) {
+				t.Errorf("HoursByOSM() = %s, expected %s", h, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHoursByOSM_Errors(t *testing.T) {
+	tests := []struct {
+		input  string
+		column int
+	}{
+		{input: "", column: 1},
+		{input: "Mo-Fr 08:00", column: 12},
+		{input: "Mo-Fr 08:00-20:00;; Sa", column: 19},
+		{input: "Mo-Xx 08:00-20:00", column: 4},
+		{input: "Mo 25:00-26:00", column: 4},
+		{input: "Mo 08:00-20:00 Foo", column: 16},
+		{input: `Mo "comment`, column: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := HoursByOSM(tt.input)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("HoursByOSM() error = %v, expected *ParseError", err)
+			}
+			if perr.Column != tt.column {
+				t.Errorf("error column = %d, expected %d: %v", perr.Column, tt.column, err)
+			}
+		})
+	}
+}
+
+func TestHours_OSMString(t *testing.T) {
+	tests := []struct {
+		name   string
+		hours  Hours
+		result string
+	}{
+		{
+			name:   "all active",
+			hours:  nil,
+			result: "24/7",
+		},
+		{
+			name:   "closed",
+			hours:  make(Hours, 24),
+			result: "off",
+		},
+		{
+			name:   "shop",
+			hours:  MustHoursByRanges("Mon-Fri 8-20; Sat 9-14"),
+			result: "Mo-Fr 08:00-20:00; Sa 09:00-14:00",
+		},
+		{
+			name:   "whole days",
+			hours:  MustHoursByRanges("Sat-Sun; Mon 9-12,13-18"),
+			result: "Mo 09:00-12:00,13:00-18:00; Sa-Su 00:00-24:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.hours.OSMString()
+			if result != tt.result {
+				t.Errorf("OSMString() = %q, expected %q", result, tt.result)
+			}
+			if back, err := HoursByOSM(result); err != nil || !back.Equal(tt.hours) {
+				t.Errorf("HoursByOSM(%q) = %s, %v expected %s", result, back, err, tt.hours)
+			}
+		})
+	}
+}
@@ -0,0 +1,473 @@
+package hourstable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Range syntax keywords
+const (
+	RangesAllActive = "24/7"
+	RangesNoActive  = "off"
+)
+
+// ParseError describes the problem of the text decoding at the exact position
+type ParseError struct {
+	// Field of the structured input (day of the JSON timetable), empty for the plain text
+	Field string
+	// Column of the input where the problem was found, starts from 1
+	Column int
+	Reason string
+}
+
+// Error implementation of error interface
+func (e *ParseError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("[hours] parse error at %s column %d: %s", e.Field, e.Column, e.Reason)
+	}
+	return fmt.Sprintf("[hours] parse error at column %d: %s", e.Column, e.Reason)
+}
+
+var weekDayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// HoursByRanges parses human readable range syntax of the hours table.
+//
+// The schedule is the list of rules separated by ';', every rule is applied in order:
+//
+//	Mon-Fri 09:00-18:00; Sat 10-14    days with hour ranges
+//	Mon,Wed,Fri 9-12,14-18            comma lists of days and hours
+//	Fri-Sat 22-02                     overnight range continues on the next day
+//	Sat-Sun                           whole days
+//	9-18                              every day of the week
+//	Mon-Fri 9-18; Wed off             'off' disables the days
+//	24/7                              all active
+//
+// Day names are case insensitive and could be short (Mon) or full (Monday).
+func HoursByRanges(s string) (Hours, error) {
+	p := rangesParser{input: []rune(s), names: weekDayNames}
+	h := make(Hours, 24)
+	if err := p.parse(h); err != nil {
+		return nil, err
+	}
+	if h.IsAllActive() {
+		return nil, nil
+	}
+	return h, nil
+}
+
+// MustHoursByRanges returns hours value or panic
+func MustHoursByRanges(s string) Hours {
+	h, err := HoursByRanges(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// RangesString returns the canonical text of the hours table in the range syntax
+// which could be parsed back by HoursByRanges. The days with the same hours are
+// grouped and the hours over midnight are joined into the overnight range (22-2)
+// when it makes the text shorter.
+func (h Hours) RangesString() string {
+	return rangesFormat.format(h)
+}
+
+type rangesParser struct {
+	input []rune
+	pos   int
+	names map[string]time.Weekday
+}
+
+func (p *rangesParser) parse(h Hours) error {
+	for {
+		if err := p.parseRule(h); err != nil {
+			return err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) {
+			return nil
+		}
+		if p.input[p.pos] != ';' {
+			return p.errorf("unexpected %q, expected ';'", p.input[p.pos])
+		}
+		p.pos++
+	}
+}
+
+func (p *rangesParser) parseRule(h Hours) error {
+	p.skipSpaces()
+	if p.pos >= len(p.input) || p.input[p.pos] == ';' {
+		return p.errorf("empty rule")
+	}
+
+	if p.hasPrefix(RangesAllActive) {
+		p.pos += len(RangesAllActive)
+		for i := range h {
+			h[i] = daysBitMask
+		}
+		return nil
+	}
+
+	var (
+		days    = daysBitMask
+		hasDays = unicode.IsLetter(p.input[p.pos]) && !p.isWord(RangesNoActive)
+	)
+	if hasDays {
+		var err error
+		if days, err = p.parseDays(); err != nil {
+			return err
+		}
+		p.skipSpaces()
+	}
+
+	switch {
+	case p.isWord(RangesNoActive):
+		p.pos += len(RangesNoActive)
+		for i := range h {
+			h[i] &= ^days
+		}
+	case p.pos < len(p.input) && unicode.IsDigit(p.input[p.pos]):
+		return p.parseHours(h, days)
+	case !hasDays:
+		return p.errorf("unexpected %q, expected days or hours", p.input[p.pos])
+	default:
+		for i := range h {
+			h[i] |= days
+		}
+	}
+	return nil
+}
+
+func (p *rangesParser) parseDays() (days byte, err error) {
+	for {
+		from, err := p.parseDay()
+		if err != nil {
+			return 0, err
+		}
+		to := from
+		if p.skipSpaces(); p.pos < len(p.input) && p.input[p.pos] == '-' {
+			p.pos++
+			p.skipSpaces()
+			if to, err = p.parseDay(); err != nil {
+				return 0, err
+			}
+		}
+		for day := from; ; day = (day + 1) % 7 {
+			days |= byte(0x01) << byte(day)
+			if day == to {
+				break
+			}
+		}
+		if p.skipSpaces(); p.pos >= len(p.input) || p.input[p.pos] != ',' {
+			return days, nil
+		}
+		p.pos++
+		p.skipSpaces()
+	}
+}
+
+func (p *rangesParser) parseDay() (time.Weekday, error) {
+	start := p.pos
+	for p.pos < len(p.input) && unicode.IsLetter(p.input[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, p.errorf("expected day of week")
+	}
+	name := strings.ToLower(string(p.input[start:p.pos]))
+	day, ok := p.names[name]
+	if !ok {
+		p.pos = start
+		return 0, p.errorf("unknown day of week %q", name)
+	}
+	return day, nil
+}
+
+func (p *rangesParser) parseHours(h Hours, days byte) error {
+	for {
+		from, err := p.parseTime()
+		if err != nil {
+			return err
+		}
+		if p.skipSpaces(); p.pos >= len(p.input) || p.input[p.pos] != '-' {
+			return p.errorf("expected '-' of the hours range")
+		}
+		p.pos++
+		p.skipSpaces()
+		start := p.pos
+		to, err := p.parseTime()
+		if err != nil {
+			return err
+		}
+		if from == to {
+			p.pos = start
+			return p.errorf("empty hours range")
+		}
+		if from >= 24 {
+			return p.errorf("range can't start at 24")
+		}
+		setHoursRange(h, days, from, to)
+		if p.skipSpaces(); p.pos >= len(p.input) || p.input[p.pos] != ',' {
+			return nil
+		}
+		p.pos++
+		p.skipSpaces()
+	}
+}
+
+func (p *rangesParser) parseTime() (int, error) {
+	start := p.pos
+	for p.pos < len(p.input) && unicode.IsDigit(p.input[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos || p.pos-start > 2 {
+		p.pos = start
+		return 0, p.errorf("expected hour")
+	}
+	hour, _ := strconv.Atoi(string(p.input[start:p.pos]))
+	if hour > 24 {
+		p.pos = start
+		return 0, p.errorf("hour %d is out of range", hour)
+	}
+	if p.pos < len(p.input) && p.input[p.pos] == ':' {
+		p.pos++
+		if p.pos+2 > len(p.input) || !unicode.IsDigit(p.input[p.pos]) || !unicode.IsDigit(p.input[p.pos+1]) {
+			return 0, p.errorf("expected minutes")
+		}
+		if p.input[p.pos] != '0' || p.input[p.pos+1] != '0' {
+			return 0, p.errorf("minutes are not supported, only whole hours")
+		}
+		p.pos += 2
+	}
+	return hour, nil
+}
+
+func (p *rangesParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *rangesParser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(p.input[p.pos:]), prefix)
+}
+
+// isWord returns true if the word is next in the input (case insensitive)
+func (p *rangesParser) isWord(word string) bool {
+	end := p.pos + len(word)
+	if end > len(p.input) || !strings.EqualFold(string(p.input[p.pos:end]), word) {
+		return false
+	}
+	return end == len(p.input) || !unicode.IsLetter(p.input[end])
+}
+
+func (p *rangesParser) errorf(format string, args ...any) error {
+	return &ParseError{Column: p.pos + 1, Reason: fmt.Sprintf(format, args...)}
+}
+
+// setHoursRange marks [from, to) hours of the days as active,
+// if to is before from then the range continues on the next day
+func setHoursRange(h Hours, days byte, from, to int) {
+	if to <= from {
+		for hour := from; hour < 24; hour++ {
+			h[hour] |= days
+		}
+		days, from = rotateWeekDays(days, 1), 0
+	}
+	for hour := from; hour < to; hour++ {
+		h[hour] |= days
+	}
+}
+
+// rangeFormat describes text format of the range based syntax
+type rangeFormat struct {
+	days      [7]string // Names of the week days
+	allActive string
+	noActive  string
+	fullDay   string // Hours of the whole day, empty to omit
+	overnight bool   // Join the hours over midnight into one range (22-2)
+	hoursFmt  func(from, to int) string
+}
+
+var rangesFormat = rangeFormat{
+	days:      [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	allActive: RangesAllActive,
+	noActive:  RangesNoActive,
+	overnight: true,
+	hoursFmt: func(from, to int) string {
+		return strconv.Itoa(from) + "-" + strconv.Itoa(to)
+	},
+}
+
+// format the hours table as rules for every group of days with the same hours,
+// the text with the overnight ranges is used if it is shorter
+func (f *rangeFormat) format(h Hours) string {
+	if h.IsAllActive() {
+		return f.allActive
+	}
+	if h.IsNoActive() {
+		return f.noActive
+	}
+
+	var rows [7]DayHours
+	for day := range rows {
+		rows[day] = h.Day(time.Weekday(day))
+	}
+	text := f.formatRows(rows, [7]int{})
+	if f.overnight {
+		if nights, ok := overnightRows(&rows); ok {
+			if s := f.formatRows(rows, nights); len(s) < len(text) {
+				text = s
+			}
+		}
+	}
+	return text
+}
+
+// overnightRows moves the hours after midnight to the day before if the day
+// ends with active hours, nights keeps the end hour of the range on the next day
+func overnightRows(rows *[7]DayHours) (nights [7]int, ok bool) {
+	var (
+		src   = *rows
+		night = DayHours(1) << 23
+	)
+	for day := range src {
+		next := (day + 1) % 7
+		if src[day]&night == 0 || src[next]&1 == 0 || src[day] == AllDayHours {
+			continue
+		}
+		from, to := 23, 1
+		for from > 0 && src[day]&(1<<(from-1)) != 0 {
+			from--
+		}
+		for to < 24 && src[next]&(1<<to) != 0 {
+			to++
+		}
+		// The range from-to must be parsed back as the overnight one
+		if to >= from {
+			continue
+		}
+		rows[next] &^= RangeDayHours(0, byte(to))
+		nights[day], ok = to, true
+	}
+	return nights, ok
+}
+
+func (f *rangeFormat) formatRows(rows [7]DayHours, nights [7]int) string {
+	var (
+		groups []byte
+		rules  []string
+	)
+	// Week starts from Monday for humans
+	for i := 1; i <= 7; i++ {
+		day := i % 7
+		if rows[day] == 0 {
+			continue
+		}
+		found := false
+		for j, days := range groups {
+			if first := firstWeekDay(days); rows[first] == rows[day] && nights[first] == nights[day] {
+				groups[j] |= byte(0x01) << byte(day)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, byte(0x01)<<byte(day))
+		}
+	}
+
+	for _, days := range groups {
+		var (
+			first = firstWeekDay(days)
+			rule  []string
+			hours = f.formatHours(rows[first], nights[first])
+		)
+		if days != daysBitMask {
+			rule = append(rule, f.formatDays(days))
+		}
+		if hours != "" || len(rule) == 0 {
+			rule = append(rule, hours)
+		}
+		rules = append(rules, strings.Join(rule, " "))
+	}
+	return strings.Join(rules, "; ")
+}
+
+// formatDays as list of the ranges in Monday first order,
+// the ranges could wrap over the week end (Sat-Mon)
+func (f *rangeFormat) formatDays(days byte) string {
+	var (
+		list  []string
+		start = 1 // Monday
+	)
+	// Move the start back to keep the range over the week end whole
+	for i := 0; i < 6 && days&(byte(0x01)<<byte(time.Monday)) != 0 &&
+		days&(byte(0x01)<<byte((start+6)%7)) != 0; i++ {
+		start = (start + 6) % 7
+	}
+	for i := 0; i < 7; {
+		day := (start + i) % 7
+		if days&(byte(0x01)<<byte(day)) == 0 {
+			i++
+			continue
+		}
+		last := i
+		for last+1 < 7 && days&(byte(0x01)<<byte((start+last+1)%7)) != 0 {
+			last++
+		}
+		if last == i {
+			list = append(list, f.days[day])
+		} else {
+			list = append(list, f.days[day]+"-"+f.days[(start+last)%7])
+		}
+		i = last + 1
+	}
+	return strings.Join(list, ",")
+}
+
+// formatHours of the day, the range till midnight ends at the night hour
+// of the next day if it is not zero
+func (f *rangeFormat) formatHours(row DayHours, night int) string {
+	if row == AllDayHours {
+		return f.fullDay
+	}
+	var list []string
+	for hour := 0; hour < 24; {
+		if row&(1<<hour) == 0 {
+			hour++
+			continue
+		}
+		to := hour + 1
+		for to < 24 && row&(1<<to) != 0 {
+			to++
+		}
+		if to == 24 && night > 0 {
+			list = append(list, f.hoursFmt(hour, night))
+		} else {
+			list = append(list, f.hoursFmt(hour, to))
+		}
+		hour = to
+	}
+	return strings.Join(list, ",")
+}
+
+func firstWeekDay(days byte) time.Weekday {
+	for day := time.Weekday(0); day < 7; day++ {
+		if days&(byte(0x01)<<byte(day)) != 0 {
+			return day
+		}
+	}
+	return 0
+}
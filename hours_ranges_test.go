@@ -0,0 +1,245 @@
+package hourstable
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHoursByRanges(t *testing.T) {
+	business := func() Hours {
+		h := make(Hours, 24)
+		for day := time.Monday; day <= time.Friday; day++ {
+			for hour := 9; hour < 18; hour++ {
+				h.SetHour(day, byte(hour), true)
+			}
+		}
+		return h
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected Hours
+	}{
+		{
+			name:     "all active",
+			input:    "24/7",
+			expected: nil,
+		},
+		{
+			name:     "no active",
+			input:    "off",
+			expected: make(Hours, 24),
+		},
+		{
+			name:     "business hours",
+			input:    "Mon-Fri 09:00-18:00",
+			expected: business(),
+		},
+		{
+			name:  "several rules",
+			input: "mon-fri 9-18; SAT 10-14",
+			expected: func() Hours {
+				h := business()
+				for hour := 10; hour < 14; hour++ {
+					h.SetHour(time.Saturday, byte(hour), true)
+				}
+				return h
+			}(),
+		},
+		{
+			name:  "comma lists",
+			input: "Mon,Wed 9-12, 14-15",
+			expected: func() Hours {
+				h := make(Hours, 24)
+				for _, day := range []time.Weekday{time.Monday, time.Wednesday} {
+					for _, hour := range []byte{9, 10, 11, 14} {
+						h.SetHour(day, hour, true)
+					}
+				}
+				return h
+			}(),
+		},
+		{
+			name:  "overnight with week wrap",
+			input: "Saturday 22-02",
+			expected: func() Hours {
+				h := make(Hours, 24)
+				h.SetHour(time.Saturday, 22, true)
+				h.SetHour(time.Saturday, 23, true)
+				h.SetHour(time.Sunday, 0, true)
+				h.SetHour(time.Sunday, 1, true)
+				return h
+			}(),
+		},
+		{
+			name:  "day range with week wrap",
+			input: "Sat-Mon",
+			expected: MustHoursByString(ActiveDayHoursString + ActiveDayHoursString +
+				strings.Repeat(DisabledDayHoursString, 4) + ActiveDayHoursString),
+		},
+		{
+			name:  "off rule",
+			input: "Mon-Fri 9-18; Wed off",
+			expected: func() Hours {
+				h := business()
+				for hour := 9; hour < 18; hour++ {
+					h.SetHour(time.Wednesday, byte(hour), false)
+				}
+				return h
+			}(),
+		},
+		{
+			name:     "all days hours",
+			input:    "0-24",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := HoursByRanges(tt.input)
+			if err != nil {
+				t.Fatalf("HoursByRanges() error = %v", err)
+			}
+			if !h.Equal(tt.expected) {
+				t.Errorf("HoursByRanges() = %s, expected %s", h, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHoursByRanges_Errors(t *testing.T) {
+	tests := []struct {
+		input  string
+		column int
+	}{
+		{input: "", column: 1},
+		{input: "Mon-Fry 9-18", column: 5},
+		{input: "Mon 9-18;", column: 10},
+		{input: "Mon 9-18 Tue", column: 10},
+		{input: "Mon 9:30-18", column: 7},
+		{input: "Mon 9-25", column: 7},
+		{input: "Mon 9-9", column: 7},
+		{input: "Mon 9", column: 6},
+		{input: "Mon 24-02", column: 10},
+		{input: "Mon-", column: 5},
+		{input: "-18", column: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := HoursByRanges(tt.input)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("HoursByRanges() error = %v, expected *ParseError", err)
+			}
+			if perr.Column != tt.column {
+				t.Errorf("error column = %d, expected %d: %v", perr.Column, tt.column, err)
+			}
+		})
+	}
+}
+
+func TestHours_RangesString(t *testing.T) {
+	tests := []struct {
+		name   string
+		hours  Hours
+		result string
+	}{
+		{
+			name:   "all active",
+			hours:  nil,
+			result: "24/7",
+		},
+		{
+			name:   "no active",
+			hours:  make(Hours, 24),
+			result: "off",
+		},
+		{
+			name:   "business hours",
+			hours:  MustHoursByRanges("Mon-Fri 09:00-18:00; Sat 10:00-14:00"),
+			result: "Mon-Fri 9-18; Sat 10-14",
+		},
+		{
+			name:   "every day",
+			hours:  MustHoursByRanges("Mon-Sun 9-12,13-18"),
+			result: "9-12,13-18",
+		},
+		{
+			name:   "whole days with week wrap",
+			hours:  MustHoursByRanges("Sun, Mon, Sat"),
+			result: "Sat-Mon",
+		},
+		{
+			name:   "separate days",
+			hours:  MustHoursByRanges("Wed 9-10; Mon 9-10; Fri 9-10; Sun 1-2"),
+			result: "Mon,Wed,Fri 9-10; Sun 1-2",
+		},
+		{
+			name:   "Sunday is the last day",
+			hours:  MustHoursByRanges("Sun 9-10; Wed 9-10"),
+			result: "Wed,Sun 9-10",
+		},
+		{
+			name:   "overnight",
+			hours:  MustHoursByRanges("Sat 22-02"),
+			result: "Sat 22-2",
+		},
+		{
+			name:   "overnight of the day",
+			hours:  MustHoursByRanges("Fri 22-2"),
+			result: "Fri 22-2",
+		},
+		{
+			name:   "overnight every day",
+			hours:  MustHoursByRanges("22-02"),
+			result: "22-2",
+		},
+		{
+			name:   "overnight with day hours",
+			hours:  MustHoursByRanges("Mon-Fri 9-13,18-1"),
+			result: "Mon-Fri 9-13,18-1",
+		},
+		{
+			name:   "overnight of one day of the group",
+			hours:  MustHoursByRanges("0-2; Fri 22-24"),
+			result: "Sun-Thu 0-2; Fri 0-2,22-2",
+		},
+		{
+			name:   "morning is longer than evening",
+			hours:  MustHoursByRanges("Mon 23-24; Tue 0-23"),
+			result: "Mon 23-24; Tue 0-23",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.hours.RangesString()
+			if result != tt.result {
+				t.Errorf("RangesString() = %q, expected %q", result, tt.result)
+			}
+			if back := MustHoursByRanges(result); !back.Equal(tt.hours) {
+				t.Errorf("HoursByRanges(%q) = %s, expected %s", result, back, tt.hours)
+			}
+		})
+	}
+}
+
+func TestHours_RangesStringRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		h := make(Hours, 24)
+		for j := range h {
+			h[j] = byte(rnd.Intn(128))
+		}
+		result := h.RangesString()
+		if back, err := HoursByRanges(result); err != nil || !back.Equal(h) {
+			t.Fatalf("HoursByRanges(%q) = %s, %v, expected %s", result, back, err, h)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package hourstable
+
+// Set operations over the hours tables.
+//
+// Nil (empty) hours is the all active table, the hours missing
+// in tables shorter than 24 bytes are inactive, which matches TestHour.
+// The result is always a new table of 24 hours or nil if all hours are active.
+
+// Union returns the hours which are active in h or h2
+func (h Hours) Union(h2 Hours) Hours {
+	return combineHours(h, h2, func(a, b byte) byte { return a | b })
+}
+
+// Intersect returns the hours which are active in h and h2
+func (h Hours) Intersect(h2 Hours) Hours {
+	return combineHours(h, h2, func(a, b byte) byte { return a & b })
+}
+
+// Subtract returns the hours which are active in h but not in h2
+func (h Hours) Subtract(h2 Hours) Hours {
+	return combineHours(h, h2, func(a, b byte) byte { return a &^ b })
+}
+
+// SymmetricDiff returns the hours which are active only in one of h and h2
+func (h Hours) SymmetricDiff(h2 Hours) Hours {
+	return combineHours(h, h2, func(a, b byte) byte { return a ^ b })
+}
+
+// Invert returns the hours which are not active in h
+func (h Hours) Invert() Hours {
+	return combineHours(h, nil, func(a, _ byte) byte { return ^a })
+}
+
+func combineHours(h, h2 Hours, op func(a, b byte) byte) Hours {
+	result := make(Hours, 24)
+	for hour := range result {
+		result[hour] = op(h.hourMask(hour), h2.hourMask(hour)) & daysBitMask
+	}
+	if result.IsAllActive() {
+		return nil
+	}
+	return result
+}
+
+// hourMask returns the week days mask of the hour
+func (h Hours) hourMask(hour int) byte {
+	if len(h) < 1 {
+		return daysBitMask
+	}
+	if hour < len(h) {
+		return h[hour] & daysBitMask
+	}
+	return 0
+}
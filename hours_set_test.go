@@ -0,0 +1,134 @@
+package hourstable
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+// anyHours generates all kinds of tables: nil, short, full and with garbage bits
+type anyHours Hours
+
+func (anyHours) Generate(rnd *rand.Rand, _ int) reflect.Value {
+	var h Hours
+	switch rnd.Intn(6) {
+	case 0: // all active
+	case 1:
+		h = make(Hours, rnd.Intn(24))
+	default:
+		h = make(Hours, 24)
+	}
+	for i := range h {
+		h[i] = byte(rnd.Intn(256))
+	}
+	return reflect.ValueOf(anyHours(h))
+}
+
+func TestHours_SetOperations(t *testing.T) {
+	var (
+		business = MustHoursByRanges("Mon-Fri 9-17")
+		morning  = MustHoursByRanges("8-12")
+		short    = MustHoursByString("1001100") // Sunday 0h, 3h, 4h
+	)
+
+	tests := []struct {
+		name     string
+		result   Hours
+		expected Hours
+	}{
+		{name: "union", result: business.Union(morning), expected: MustHoursByRanges("Mon-Fri 8-17; Sat-Sun 8-12")},
+		{name: "union with all", result: business.Union(nil), expected: nil},
+		{name: "intersect", result: business.Intersect(morning), expected: MustHoursByRanges("Mon-Fri 9-12")},
+		{name: "intersect with all", result: Hours(nil).Intersect(business), expected: business},
+		{name: "intersect with short", result: short.Intersect(MustHoursByRanges("Sun 4-5")), expected: MustHoursByRanges("Sun 4-5")},
+		{name: "subtract", result: business.Subtract(morning), expected: MustHoursByRanges("Mon-Fri 12-17")},
+		{name: "subtract all", result: business.Subtract(nil), expected: make(Hours, 24)},
+		{name: "subtract from all", result: Hours(nil).Subtract(business), expected: business.Invert()},
+		{name: "symmetric diff", result: business.SymmetricDiff(morning), expected: MustHoursByRanges("Mon-Fri 8-9,12-17; Sat-Sun 8-12")},
+		{name: "invert", result: business.Invert(), expected: MustHoursByRanges("Mon-Fri 0-9,17-24; Sat-Sun")},
+		{name: "invert all", result: Hours(nil).Invert(), expected: make(Hours, 24)},
+		{name: "invert none", result: make(Hours, 24).Invert(), expected: nil},
+		{name: "invert short", result: short.Invert(), expected: MustHoursByRanges("Sun 1-3,5-24; Mon-Sat")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.result.Equal(tt.expected) {
+				t.Errorf("result = %s, expected %s", tt.result.RangesString(), tt.expected.RangesString())
+			}
+		})
+	}
+
+	// Operations don't change the arguments
+	if business.Union(morning); !business.Equal(MustHoursByRanges("Mon-Fri 9-17")) {
+		t.Errorf("Union() should not change the receiver")
+	}
+}
+
+func TestHours_SetLaws(t *testing.T) {
+	// sameHours compares the tables by the hours which are really tested
+	sameHours := func(a, b Hours) bool {
+		for day := time.Weekday(0); day < 7; day++ {
+			for hour := byte(0); hour < 24; hour++ {
+				if a.TestHour(day, hour) != b.TestHour(day, hour) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	laws := map[string]any{
+		"union commutative": func(a, b anyHours) bool {
+			return sameHours(Hours(a).Union(Hours(b)), Hours(b).Union(Hours(a)))
+		},
+		"intersect commutative": func(a, b anyHours) bool {
+			return sameHours(Hours(a).Intersect(Hours(b)), Hours(b).Intersect(Hours(a)))
+		},
+		"union associative": func(a, b, c anyHours) bool {
+			return sameHours(Hours(a).Union(Hours(b)).Union(Hours(c)), Hours(a).Union(Hours(b).Union(Hours(c))))
+		},
+		"intersect associative": func(a, b, c anyHours) bool {
+			return sameHours(Hours(a).Intersect(Hours(b)).Intersect(Hours(c)), Hours(a).Intersect(Hours(b).Intersect(Hours(c))))
+		},
+		"distributive": func(a, b, c anyHours) bool {
+			return sameHours(Hours(a).Intersect(Hours(b).Union(Hours(c))),
+				Hours(a).Intersect(Hours(b)).Union(Hours(a).Intersect(Hours(c))))
+		},
+		"de morgan": func(a, b anyHours) bool {
+			return sameHours(Hours(a).Union(Hours(b)).Invert(), Hours(a).Invert().Intersect(Hours(b).Invert()))
+		},
+		"double invert": func(a anyHours) bool {
+			return sameHours(Hours(a).Invert().Invert(), Hours(a))
+		},
+		"subtract is intersect with inverted": func(a, b anyHours) bool {
+			return sameHours(Hours(a).Subtract(Hours(b)), Hours(a).Intersect(Hours(b).Invert()))
+		},
+		"symmetric diff is union minus intersect": func(a, b anyHours) bool {
+			return sameHours(Hours(a).SymmetricDiff(Hours(b)), Hours(a).Union(Hours(b)).Subtract(Hours(a).Intersect(Hours(b))))
+		},
+		"identities": func(a anyHours) bool {
+			return sameHours(Hours(a).Union(make(Hours, 24)), Hours(a)) &&
+				sameHours(Hours(a).Intersect(nil), Hours(a)) &&
+				Hours(a).Union(nil).IsAllActive() &&
+				Hours(a).Intersect(make(Hours, 24)).IsNoActive() &&
+				Hours(a).Union(Hours(a).Invert()).IsAllActive() &&
+				Hours(a).SymmetricDiff(Hours(a)).IsNoActive()
+		},
+		"same as TestHour": func(a, b anyHours, day uint8, hour uint8) bool {
+			d, hr := time.Weekday(day%7), hour%24
+			return Hours(a).Union(Hours(b)).TestHour(d, hr) == (Hours(a).TestHour(d, hr) || Hours(b).TestHour(d, hr)) &&
+				Hours(a).Intersect(Hours(b)).TestHour(d, hr) == (Hours(a).TestHour(d, hr) && Hours(b).TestHour(d, hr))
+		},
+	}
+
+	for name, law := range laws {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(law, &quick.Config{MaxCount: 500}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package hourstable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HoursByStringStrict decodes the hours string and rejects the invalid input.
+// Only "*", the compact format or exactly 7*24 symbols of '0' and '1' are accepted,
+// the problem is returned as *ParseError with the position and reason.
+func HoursByStringStrict(s string) (Hours, error) {
+	if s == AllActiveHoursString {
+		return nil, nil
+	}
+	if strings.HasPrefix(s, CompactHoursPrefix) {
+		h, err := HoursByCompactString(s)
+		if err != nil {
+			return nil, &ParseError{Column: len(CompactHoursPrefix) + 1, Reason: err.Error()}
+		}
+		return h, nil
+	}
+	h := make(Hours, 24)
+	if err := strictRow(s, 7*24, func(i int) {
+		h[i%24] |= byte(0x01) << byte(i/24)
+	}); err != nil {
+		return nil, err
+	}
+	if h.IsAllActive() {
+		return nil, nil
+	}
+	return h, nil
+}
+
+// MustHoursByStringStrict returns hours value or panic
+func MustHoursByStringStrict(s string) Hours {
+	h, err := HoursByStringStrict(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// HoursByJSONStrict decodes JSON format of timetable and rejects the invalid input.
+// Unknown fields are not allowed and every day must be "*", "" or exactly 24 symbols
+// of '0' and '1', the day problem is returned as *ParseError with the Field of the day.
+func HoursByJSONStrict(data []byte) (Hours, error) {
+	var (
+		timetable timetableJSON
+		decoder   = json.NewDecoder(bytes.NewReader(data))
+	)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&timetable); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("[hours_json] unexpected data after the timetable")
+	}
+	return timetable.ToHoursStrict()
+}
+
+// ToHoursStrict converts the timetable with validation of every day
+func (tt *timetableJSON) ToHoursStrict() (Hours, error) {
+	hours := make(Hours, 24)
+	for _, day := range []struct {
+		name    string
+		hours   string
+		weekDay time.Weekday
+	}{
+		{"mon", tt.Monday, time.Monday},
+		{"tue", tt.Tuesday, time.Tuesday},
+		{"wed", tt.Wednesday, time.Wednesday},
+		{"thu", tt.Thursday, time.Thursday},
+		{"fri", tt.Friday, time.Friday},
+		{"sat", tt.Saturday, time.Saturday},
+		{"sun", tt.Sunday, time.Sunday},
+	} {
+		if day.hours != AllActiveHoursString && day.hours != "" {
+			if err := strictRow(day.hours, 24, func(int) {}); err != nil {
+				err.Field = day.name
+				return nil, err
+			}
+		}
+		hoursToBinary(hours, day.hours, day.weekDay)
+	}
+	return hours, nil
+}
+
+// StrictHours is the Hours with the strict decoding in JSON, YAML and database/sql
+type StrictHours Hours
+
+// String implementation of fmt.Stringer
+func (h StrictHours) String() string {
+	return Hours(h).String()
+}
+
+// Value implementation of valuer for database/sql
+func (h StrictHours) Value() (driver.Value, error) {
+	return Hours(h).Value()
+}
+
+// Scan - Implement the database/sql scanner interface
+func (h *StrictHours) Scan(value any) (err error) {
+	if value == nil {
+		*h = nil
+		return nil
+	}
+
+	var newHours Hours
+	switch v := value.(type) {
+	case []byte:
+		if newHours, err = HoursByStringStrict(string(v)); err == nil {
+			*h = StrictHours(newHours)
+		}
+	case string:
+		if newHours, err = HoursByStringStrict(v); err == nil {
+			*h = StrictHours(newHours)
+		}
+	default:
+		err = fmt.Errorf("[hours] unsupported decode type %T", value)
+	}
+	return
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (h StrictHours) MarshalJSON() ([]byte, error) {
+	return Hours(h).MarshalJSON()
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (h *StrictHours) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*h = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	newHours, err := HoursByStringStrict(s)
+	if err != nil {
+		return err
+	}
+	*h = StrictHours(newHours)
+	return nil
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (h StrictHours) MarshalYAML() (any, error) {
+	return Hours(h).MarshalYAML()
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (h *StrictHours) UnmarshalYAML(node *yaml.Node) error {
+	if isYAMLNull(node) {
+		*h = nil
+		return nil
+	}
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("[hours] line %d: expected string of hours", node.Line)
+	}
+	newHours, err := HoursByStringStrict(node.Value)
+	if err != nil {
+		return err
+	}
+	*h = StrictHours(newHours)
+	return nil
+}
+
+// StrictHoursObject is the HoursObject with the strict decoding in JSON, YAML and database/sql
+type StrictHoursObject HoursObject
+
+// String implementation of fmt.Stringer
+func (h StrictHoursObject) String() string {
+	return HoursObject(h).String()
+}
+
+// Value implementation of valuer for database/sql
+func (h StrictHoursObject) Value() (driver.Value, error) {
+	return HoursObject(h).Value()
+}
+
+// Scan - Implement the database/sql scanner interface
+func (h *StrictHoursObject) Scan(value any) (err error) {
+	if value == nil {
+		*h = nil
+		return nil
+	}
+
+	var newHours Hours
+	switch v := value.(type) {
+	case []byte:
+		if newHours, err = HoursByJSONStrict(v); err == nil {
+			*h = StrictHoursObject(newHours)
+		}
+	case string:
+		if newHours, err = HoursByJSONStrict([]byte(v)); err == nil {
+			*h = StrictHoursObject(newHours)
+		}
+	default:
+		err = fmt.Errorf("[hours_json] unsupported decode type %T", value)
+	}
+	return
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (h StrictHoursObject) MarshalJSON() ([]byte, error) {
+	return HoursObject(h).MarshalJSON()
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (h *StrictHoursObject) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*h = nil
+		return nil
+	}
+	newHours, err := HoursByJSONStrict(data)
+	if err != nil {
+		return err
+	}
+	*h = StrictHoursObject(newHours)
+	return nil
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (h StrictHoursObject) MarshalYAML() (any, error) {
+	return HoursObject(h).MarshalYAML()
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (h *StrictHoursObject) UnmarshalYAML(node *yaml.Node) error {
+	if isYAMLNull(node) {
+		*h = nil
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("[hours_json] line %d: expected mapping of days", node.Line)
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		switch key := node.Content[i].Value; key {
+		case "mon", "tue", "wed", "thu", "fri", "sat", "sun":
+		default:
+			return fmt.Errorf("[hours_json] line %d: unknown field %q", node.Content[i].Line, key)
+		}
+	}
+	var timetable timetableJSON
+	if err := node.Decode(&timetable); err != nil {
+		return err
+	}
+	newHours, err := timetable.ToHoursStrict()
+	if err != nil {
+		return err
+	}
+	*h = StrictHoursObject(newHours)
+	return nil
+}
+
+// strictRow validates that the row has exactly size symbols of '0' and '1',
+// set is called for every active position
+func strictRow(s string, size int, set func(i int)) *ParseError {
+	i := 0
+	for pos := 0; pos < len(s); i++ {
+		c, width := utf8.DecodeRuneInString(s[pos:])
+		switch {
+		case i >= size:
+			return &ParseError{Column: i + 1, Reason: fmt.Sprintf("too long input, expected %d hours", size)}
+		case c == utf8.RuneError && width <= 1:
+			return &ParseError{Column: i + 1, Reason: "invalid UTF-8 encoding"}
+		case c >= utf8.RuneSelf:
+			return &ParseError{Column: i + 1, Reason: fmt.Sprintf("non-ASCII character %q", c)}
+		case c == '1':
+			set(i)
+		case c != '0':
+			return &ParseError{Column: i + 1, Reason: fmt.Sprintf("invalid character %q, expected '0' or '1'", c)}
+		}
+		pos += width
+	}
+	if i < size {
+		return &ParseError{Column: i + 1, Reason: fmt.Sprintf("too short input: %d hours, expected %d", i, size)}
+	}
+	return nil
+}
+
+// isYAMLNull returns true for the null value (~, null or empty) like the JSON null
+func isYAMLNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+var (
+	_ json.Marshaler   = (StrictHours)(nil)
+	_ json.Unmarshaler = (*StrictHours)(nil)
+	_ yaml.Marshaler   = (StrictHours)(nil)
+	_ yaml.Unmarshaler = (*StrictHours)(nil)
+	_ driver.Valuer    = (StrictHours)(nil)
+	_ sql.Scanner      = (*StrictHours)(nil)
+	_ json.Marshaler   = (StrictHoursObject)(nil)
+	_ json.Unmarshaler = (*StrictHoursObject)(nil)
+	_ yaml.Marshaler   = (StrictHoursObject)(nil)
+	_ yaml.Unmarshaler = (*StrictHoursObject)(nil)
+	_ driver.Valuer    = (StrictHoursObject)(nil)
+	_ sql.Scanner      = (*StrictHoursObject)(nil)
+)
@@ -0,0 +1,173 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestHoursByStringStrict(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected Hours
+		column   int
+	}{
+		{name: "all active", input: "*", expected: nil},
+		{name: "all active week", input: ActiveWeekHoursString, expected: nil},
+		{name: "valid", input: business.String(), expected: business},
+		{name: "empty", input: "", column: 1},
+		{name: "too short", input: strings.Repeat("0", 24), column: 25},
+		{name: "too long", input: strings.Repeat("0", 7*24+1), column: 7*24 + 1},
+		{name: "invalid character", input: strings.Repeat("0", 10) + "x" + strings.Repeat("0", 7*24-11), column: 11},
+		{name: "non-ASCII", input: "0１" + strings.Repeat("0", 7*24-2), column: 2},
+		{name: "invalid UTF-8", input: "00\xff" + strings.Repeat("0", 7*24-3), column: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := HoursByStringStrict(tt.input)
+			if tt.column == 0 {
+				if err != nil {
+					t.Fatalf("HoursByStringStrict() error = %v", err)
+				}
+				if !h.Equal(tt.expected) {
+					t.Errorf("HoursByStringStrict() = %v, expected %v", h, tt.expected)
+				}
+				return
+			}
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("HoursByStringStrict() error = %v, expected *ParseError", err)
+			}
+			if perr.Column != tt.column {
+				t.Errorf("HoursByStringStrict() error column = %d, expected %d: %v", perr.Column, tt.column, err)
+			}
+			if h != nil {
+				t.Errorf("HoursByStringStrict() = %v, expected nil with error", h)
+			}
+		})
+	}
+}
+
+func TestHoursByString_TooLong(t *testing.T) {
+	h, err := HoursByString(strings.Repeat("1", 7*24+1))
+	if err != ErrTooMuchHoursForDecode {
+		t.Fatalf("HoursByString() error = %v, expected %v", err, ErrTooMuchHoursForDecode)
+	}
+	if h != nil {
+		t.Errorf("HoursByString() = %v, expected nil with error", h)
+	}
+}
+
+func TestHoursByJSONStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		field   string
+		wantErr bool
+	}{
+		{name: "valid", input: `{"mon":"000000000111111110000000","sun":"*","sat":""}`},
+		{name: "short day", input: `{"mon":"0000001"}`, field: "mon", wantErr: true},
+		{name: "invalid day", input: `{"tue":"00000000011111111000000a"}`, field: "tue", wantErr: true},
+		{name: "unknown field", input: `{"monday":"*"}`, wantErr: true},
+		{name: "trailing data", input: `{"mon":"*"} {}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := HoursByJSONStrict([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HoursByJSONStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var perr *ParseError
+			if tt.field != "" && (!errors.As(err, &perr) || perr.Field != tt.field) {
+				t.Errorf("HoursByJSONStrict() error = %v, expected *ParseError of %s", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestStrictHours_Decode(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17")
+
+	type config struct {
+		Hours  StrictHours       `json:"hours" yaml:"hours"`
+		Object StrictHoursObject `json:"object" yaml:"object"`
+	}
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(config{Hours: StrictHours(business), Object: StrictHoursObject(business)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded config
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		if !Hours(decoded.Hours).Equal(business) || !Hours(decoded.Object).Equal(business) {
+			t.Errorf("decoded = %v, expected %v", decoded, business)
+		}
+		if err = json.Unmarshal([]byte(`{"hours":"0101"}`), &decoded); err == nil {
+			t.Error("expected error of the short hours")
+		}
+		if err = json.Unmarshal([]byte(`{"object":{"mon":"01"}}`), &decoded); err == nil {
+			t.Error("expected error of the short day")
+		}
+		decoded = config{Hours: StrictHours(business), Object: StrictHoursObject(business)}
+		if err = json.Unmarshal([]byte(`{"hours":null,"object":null}`), &decoded); err != nil || decoded.Hours != nil || decoded.Object != nil {
+			t.Errorf("json.Unmarshal(null) = %v, %v, expected nil", decoded, err)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := yaml.Marshal(config{Hours: StrictHours(business), Object: StrictHoursObject(business)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded config
+		if err = yaml.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("yaml.Unmarshal() error = %v", err)
+		}
+		if !Hours(decoded.Hours).Equal(business) || !Hours(decoded.Object).Equal(business) {
+			t.Errorf("decoded = %v, expected %v", decoded, business)
+		}
+		if err = yaml.Unmarshal([]byte("hours: '0101'"), &decoded); err == nil {
+			t.Error("expected error of the short hours")
+		}
+		if err = yaml.Unmarshal([]byte("object:\n  monday: '*'"), &decoded); err == nil {
+			t.Error("expected error of the unknown day")
+		}
+		for _, null := range []string{"hours: ~\nobject: ~", "hours: null\nobject: null", "hours:\nobject:"} {
+			decoded = config{Hours: StrictHours(business), Object: StrictHoursObject(business)}
+			if err = yaml.Unmarshal([]byte(null), &decoded); err != nil || decoded.Hours != nil || decoded.Object != nil {
+				t.Errorf("yaml.Unmarshal(%q) = %v, %v, expected nil", null, decoded, err)
+			}
+		}
+		if err = yaml.Unmarshal([]byte("hours: '~'"), &decoded); err == nil {
+			t.Error("expected error of the quoted null string")
+		}
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		var h StrictHours
+		if err := h.Scan([]byte(business.String())); err != nil || !Hours(h).Equal(business) {
+			t.Errorf("Scan() = %v, %v, expected %v", h, err, business)
+		}
+		if err := h.Scan("garbage"); err == nil {
+			t.Error("expected error of the garbage")
+		}
+		var o StrictHoursObject
+		if err := o.Scan(HoursObject(business).String()); err != nil || !Hours(o).Equal(business) {
+			t.Errorf("Scan() = %v, %v, expected %v", o, err, business)
+		}
+		if err := o.Scan(`{"mon":"x"}`); err == nil {
+			t.Error("expected error of the invalid day")
+		}
+	})
+}
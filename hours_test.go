@@ -881,3 +881,75 @@ func Benchmark_Hours(b *testing.B) {
 		}
 	})
 }
+
+func TestHours_Rotate(t *testing.T) {
+	tests := []struct {
+		name     string
+		hours    Hours
+		shift    int
+		expected Hours
+	}{
+		{
+			name:     "nil hours",
+			hours:    nil,
+			shift:    5,
+			expected: nil,
+		},
+		{
+			name:     "no shift",
+			hours:    MustHoursByString("000000000111111111000000"),
+			shift:    0,
+			expected: MustHoursByString("000000000111111111000000"),
+		},
+		{
+			name:     "UTC+3 to UTC",
+			hours:    MustHoursByString(DisabledDayHoursString + "000000000111111111000000"),
+			shift:    -3,
+			expected: MustHoursByString(DisabledDayHoursString + "000000111111111000000000"),
+		},
+		{
+			name:     "carry to the next day",
+			hours:    MustHoursByString(DisabledDayHoursString + "000000000000000000000011"),
+			shift:    3,
+			expected: MustHoursByString(DisabledDayHoursString + DisabledDayHoursString + "011000000000000000000000"),
+		},
+		{
+			name: "Saturday to Sunday carry",
+			hours: MustHoursByString(DisabledDayHoursString + DisabledDayHoursString + DisabledDayHoursString +
+				DisabledDayHoursString + DisabledDayHoursString + DisabledDayHoursString + "000000000000000000000001"),
+			shift:    1,
+			expected: MustHoursByString("100000000000000000000000"),
+		},
+		{
+			name:  "Sunday to Saturday carry",
+			hours: MustHoursByString("100000000000000000000000"),
+			shift: -1,
+			expected: MustHoursByString(DisabledDayHoursString + DisabledDayHoursString + DisabledDayHoursString +
+				DisabledDayHoursString + DisabledDayHoursString + DisabledDayHoursString + "000000000000000000000001"),
+		},
+		{
+			name:     "full week",
+			hours:    MustHoursByString("000000000111111111000000"),
+			shift:    -7 * 24 * 3,
+			expected: MustHoursByString("000000000111111111000000"),
+		},
+		{
+			name:     "several days",
+			hours:    MustHoursByString("100000000000000000000000"),
+			shift:    2*24 + 5,
+			expected: MustHoursByString(DisabledDayHoursString + DisabledDayHoursString + "000001000000000000000000"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.hours.Rotate(tt.shift)
+			if !result.Equal(tt.expected) {
+				t.Errorf("Rotate(%d) = %s, expected %s", tt.shift, result, tt.expected)
+			}
+			if back := result.Rotate(-tt.shift); !back.Equal(tt.hours) {
+				t.Errorf("Rotate(%d) back = %s, expected %s", -tt.shift, back, tt.hours)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package hourstable
+
+import (
+	"bytes"
+	"encoding"
+)
+
+// MarshalText implements the functionality of encoding.TextMarshaler interface,
+// the text is the same as String()
+func (h Hours) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText implements the functionality of encoding.TextUnmarshaler interface,
+// all formats of HoursByString are accepted
+func (h *Hours) UnmarshalText(text []byte) error {
+	newHours, err := HoursByString(string(text))
+	if err != nil {
+		return err
+	}
+	*h = newHours
+	return nil
+}
+
+// MarshalText implements the functionality of encoding.TextMarshaler interface,
+// the text is the same as String() (JSON object)
+func (h HoursObject) MarshalText() ([]byte, error) {
+	return h.MarshalJSON()
+}
+
+// UnmarshalText implements the functionality of encoding.TextUnmarshaler interface,
+// the JSON object and all formats of HoursByString are accepted
+func (h *HoursObject) UnmarshalText(text []byte) error {
+	var (
+		newHours Hours
+		err      error
+	)
+	if trimmed := bytes.TrimSpace(text); len(trimmed) > 0 && trimmed[0] == '{' {
+		newHours, err = HoursByJSON(trimmed)
+	} else {
+		newHours, err = HoursByString(string(text))
+	}
+	if err != nil {
+		return err
+	}
+	*h = HoursObject(newHours)
+	return nil
+}
+
+var (
+	_ encoding.TextMarshaler   = (Hours)(nil)
+	_ encoding.TextUnmarshaler = (*Hours)(nil)
+	_ encoding.TextMarshaler   = (HoursObject)(nil)
+	_ encoding.TextUnmarshaler = (*HoursObject)(nil)
+)
@@ -0,0 +1,168 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+func TestHours_Text(t *testing.T) {
+	for _, tt := range hourstest.Tables() {
+		h := Hours(tt.Hours)
+		t.Run(tt.Name, func(t *testing.T) {
+			text, err := h.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() error = %v", err)
+			}
+			if string(text) != h.String() {
+				t.Errorf("MarshalText() = %s, expected %s", text, h.String())
+			}
+
+			var decoded Hours
+			if err = decoded.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText() error = %v", err)
+			}
+			if !decoded.Equal(h) {
+				t.Errorf("UnmarshalText() = %v, expected %v", decoded, h)
+			}
+
+			var object HoursObject
+			if text, err = HoursObject(h).MarshalText(); err != nil {
+				t.Fatalf("HoursObject.MarshalText() error = %v", err)
+			}
+			if string(text) != HoursObject(h).String() {
+				t.Errorf("HoursObject.MarshalText() = %s, expected %s", text, HoursObject(h).String())
+			}
+			if err = object.UnmarshalText(text); err != nil {
+				t.Fatalf("HoursObject.UnmarshalText() error = %v", err)
+			}
+			if !object.Equal(h) {
+				t.Errorf("HoursObject.UnmarshalText() = %v, expected %v", object, h)
+			}
+		})
+	}
+}
+
+func TestHours_TextDecode(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17")
+
+	tests := []struct {
+		name  string
+		text  string
+		hours Hours
+	}{
+		{name: "empty", text: "", hours: nil},
+		{name: "star", text: "*", hours: nil},
+		{name: "all week", text: ActiveWeekHoursString, hours: nil},
+		{name: "short", text: "1", hours: Hours{0x01}},
+		{name: "short day", text: strings.Repeat("0", 23) + "1", hours: MustHoursByRanges("Sun 23-24")},
+		{name: "unknown symbols", text: "1x1", hours: Hours{0x01, 0x00, 0x01}},
+		{name: "compact", text: business.CompactString(), hours: business},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h Hours
+			if err := h.UnmarshalText([]byte(tt.text)); err != nil || !h.Equal(tt.hours) {
+				t.Errorf("UnmarshalText(%q) = %v, %v, expected %v", tt.text, h, err, tt.hours)
+			}
+			var object HoursObject
+			if err := object.UnmarshalText([]byte(tt.text)); err != nil || !object.Equal(tt.hours) {
+				t.Errorf("HoursObject.UnmarshalText(%q) = %v, %v, expected %v", tt.text, object, err, tt.hours)
+			}
+		})
+	}
+
+	// The JSON object is accepted by HoursObject only, even after spaces
+	var object HoursObject
+	if err := object.UnmarshalText([]byte(" \n" + HoursObject(business).String())); err != nil || !object.Equal(business) {
+		t.Errorf("HoursObject.UnmarshalText() = %v, %v, expected %v", object, err, business)
+	}
+
+	var h Hours
+	if err := h.UnmarshalText([]byte(strings.Repeat("1", 7*24+1))); !errors.Is(err, ErrTooMuchHoursForDecode) {
+		t.Errorf("UnmarshalText() error = %v, expected %v", err, ErrTooMuchHoursForDecode)
+	}
+	if err := object.UnmarshalText([]byte(`{"mon":`)); err == nil {
+		t.Error("HoursObject.UnmarshalText() expected error of the broken JSON")
+	}
+}
+
+func TestHours_TextFormats(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17")
+	weekend := MustHoursByRanges("Sat-Sun 10-16")
+
+	t.Run("json map", func(t *testing.T) {
+		source := map[string]Hours{"business": business, "weekend": weekend}
+		data, err := json.Marshal(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded map[string]Hours
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		for key, h := range source {
+			if !decoded[key].Equal(h) {
+				t.Errorf("decoded[%s] = %v, expected %v", key, decoded[key], h)
+			}
+		}
+	})
+
+	t.Run("xml attribute", func(t *testing.T) {
+		type campaign struct {
+			XMLName xml.Name    `xml:"campaign"`
+			Hours   Hours       `xml:"hours,attr"`
+			Object  HoursObject `xml:"object"`
+		}
+		data, err := xml.Marshal(campaign{Hours: business, Object: HoursObject(weekend)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded campaign
+		if err = xml.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("xml.Unmarshal(%s) error = %v", data, err)
+		}
+		if !decoded.Hours.Equal(business) || !decoded.Object.Equal(weekend) {
+			t.Errorf("xml decoded = %v, %v, expected %v, %v", decoded.Hours, decoded.Object, business, weekend)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := yaml.Marshal(map[string]any{"hours": business, "object": HoursObject(weekend)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded struct {
+			Hours  Hours       `yaml:"hours"`
+			Object HoursObject `yaml:"object"`
+		}
+		if err = yaml.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Hours.Equal(business) || !decoded.Object.Equal(weekend) {
+			t.Errorf("yaml decoded = %v, %v, expected %v, %v", decoded.Hours, decoded.Object, business, weekend)
+		}
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		var (
+			h  Hours
+			fs = flag.NewFlagSet("test", flag.ContinueOnError)
+		)
+		fs.TextVar(&h, "hours", Hours(nil), "active hours")
+		if err := fs.Parse([]string{"-hours", business.CompactString()}); err != nil {
+			t.Fatal(err)
+		}
+		if !h.Equal(business) {
+			t.Errorf("flag value = %v, expected %v", h, business)
+		}
+	})
+}
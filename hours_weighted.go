@@ -0,0 +1,235 @@
+package hourstable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrTooMuchWeightsForDecode when the day contains more than 24 weights
+var ErrTooMuchWeightsForDecode = errors.New("[hours_weighted] too much weights for decode, more then 24")
+
+// WeightedHours is the grid of weights (multipliers) of every hour of the week
+// indexed by [weekDay][hour]. The zero value has all weights equal to 0.
+// The read methods use the pointer receiver to avoid the copy of the grid on every lookup.
+type WeightedHours [7][24]float64
+
+// NewWeightedHours returns the grid with the same weight of every hour
+func NewWeightedHours(weight float64) (w WeightedHours) {
+	w.SetHours(nil, weight)
+	return w
+}
+
+// WeightedHoursByHours returns the grid with the weight of the active hours
+// and 0 for the inactive hours
+func WeightedHoursByHours(h Hours, weight float64) (w WeightedHours) {
+	w.SetHours(h, weight)
+	return w
+}
+
+// WeightedHoursByJSON decodes JSON format of weighted timetable
+func WeightedHoursByJSON(data []byte) (w WeightedHours, err error) {
+	var timetable weightedJSON
+	if err = json.Unmarshal(data, &timetable); err != nil {
+		return w, err
+	}
+	err = timetable.ToWeightedHours(&w)
+	return w, err
+}
+
+// String implementation of fmt.Stringer
+func (w WeightedHours) String() string {
+	data, _ := w.MarshalJSON()
+	return string(data)
+}
+
+// Weight of the hour
+func (w *WeightedHours) Weight(weekDay time.Weekday, hour byte) float64 {
+	if weekDay < 0 || weekDay > time.Saturday || hour > 23 {
+		return 0
+	}
+	return w[weekDay][hour]
+}
+
+// WeightTime returns the weight of the hour of the time
+func (w *WeightedHours) WeightTime(t time.Time) float64 {
+	return w[t.Weekday()][t.Hour()]
+}
+
+// SetWeight of the hour
+func (w *WeightedHours) SetWeight(weekDay time.Weekday, hour byte, weight float64) {
+	if weekDay < 0 || weekDay > time.Saturday || hour > 23 {
+		return
+	}
+	w[weekDay][hour] = weight
+}
+
+// SetHours sets the weight of every active hour of the table
+func (w *WeightedHours) SetHours(h Hours, weight float64) {
+	for weekDay := time.Sunday; weekDay <= time.Saturday; weekDay++ {
+		for hour := byte(0); hour < 24; hour++ {
+			if h.TestHour(weekDay, hour) {
+				w[weekDay][hour] = weight
+			}
+		}
+	}
+}
+
+// Hours returns the table of hours with the weight above the threshold
+func (w *WeightedHours) Hours(threshold float64) Hours {
+	h := make(Hours, 24)
+	for weekDay := time.Sunday; weekDay <= time.Saturday; weekDay++ {
+		for hour := byte(0); hour < 24; hour++ {
+			if w[weekDay][hour] > threshold {
+				h.SetHour(weekDay, hour, true)
+			}
+		}
+	}
+	return h
+}
+
+// Mul returns the grid of the products of the weights
+func (w *WeightedHours) Mul(w2 *WeightedHours) WeightedHours {
+	return w.combine(w2, func(a, b float64) float64 { return a * b })
+}
+
+// Min returns the grid of the minimal weights
+func (w *WeightedHours) Min(w2 *WeightedHours) WeightedHours {
+	return w.combine(w2, math.Min)
+}
+
+// Max returns the grid of the maximal weights
+func (w *WeightedHours) Max(w2 *WeightedHours) WeightedHours {
+	return w.combine(w2, math.Max)
+}
+
+func (w *WeightedHours) combine(w2 *WeightedHours, fn func(a, b float64) float64) (res WeightedHours) {
+	for weekDay := range w {
+		for hour := range w[weekDay] {
+			res[weekDay][hour] = fn(w[weekDay][hour], w2[weekDay][hour])
+		}
+	}
+	return res
+}
+
+// Equal comarison of two weighted tables
+func (w *WeightedHours) Equal(w2 *WeightedHours) bool {
+	return *w == *w2
+}
+
+// Value implementation of valuer for database/sql
+func (w WeightedHours) Value() (driver.Value, error) {
+	return w.MarshalJSON()
+}
+
+// Scan - Implement the database/sql scanner interface
+func (w *WeightedHours) Scan(value any) (err error) {
+	if value == nil {
+		*w = WeightedHours{}
+		return nil
+	}
+
+	var newWeights WeightedHours
+	switch v := value.(type) {
+	case []byte:
+		if newWeights, err = WeightedHoursByJSON(v); err == nil {
+			*w = newWeights
+		}
+	case string:
+		if newWeights, err = WeightedHoursByJSON([]byte(v)); err == nil {
+			*w = newWeights
+		}
+	default:
+		err = fmt.Errorf("[hours_weighted] unsupported decode type %T", value)
+	}
+	return
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (w WeightedHours) MarshalJSON() ([]byte, error) {
+	var timetable weightedJSON
+	timetable.FromWeightedHours(&w)
+	return json.Marshal(&timetable)
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (w *WeightedHours) UnmarshalJSON(data []byte) error {
+	newWeights, err := WeightedHoursByJSON(data)
+	if err != nil {
+		return err
+	}
+	*w = newWeights
+	return nil
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (w WeightedHours) MarshalYAML() (any, error) {
+	var timetable weightedJSON
+	timetable.FromWeightedHours(&w)
+	return &timetable, nil
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (w *WeightedHours) UnmarshalYAML(node *yaml.Node) error {
+	var (
+		timetable  weightedJSON
+		newWeights WeightedHours
+	)
+	if err := node.Decode(&timetable); err != nil {
+		return err
+	}
+	if err := timetable.ToWeightedHours(&newWeights); err != nil {
+		return err
+	}
+	*w = newWeights
+	return nil
+}
+
+// weightedJSON has the same layout as timetableJSON with the weights
+// of the hours instead of the string of the day. The days without weights are omitted.
+type weightedJSON struct {
+	Monday    []float64 `json:"mon,omitempty" yaml:"mon,omitempty,flow"`
+	Tuesday   []float64 `json:"tue,omitempty" yaml:"tue,omitempty,flow"`
+	Wednesday []float64 `json:"wed,omitempty" yaml:"wed,omitempty,flow"`
+	Thursday  []float64 `json:"thu,omitempty" yaml:"thu,omitempty,flow"`
+	Friday    []float64 `json:"fri,omitempty" yaml:"fri,omitempty,flow"`
+	Saturday  []float64 `json:"sat,omitempty" yaml:"sat,omitempty,flow"`
+	Sunday    []float64 `json:"sun,omitempty" yaml:"sun,omitempty,flow"`
+}
+
+func (tt *weightedJSON) days() [7]*[]float64 {
+	return [7]*[]float64{&tt.Sunday, &tt.Monday, &tt.Tuesday, &tt.Wednesday, &tt.Thursday, &tt.Friday, &tt.Saturday}
+}
+
+func (tt *weightedJSON) ToWeightedHours(w *WeightedHours) error {
+	for weekDay, weights := range tt.days() {
+		if len(*weights) > 24 {
+			return ErrTooMuchWeightsForDecode
+		}
+		copy(w[weekDay][:], *weights)
+	}
+	return nil
+}
+
+func (tt *weightedJSON) FromWeightedHours(w *WeightedHours) {
+	for weekDay, weights := range tt.days() {
+		if w[weekDay] != [24]float64{} {
+			*weights = append([]float64(nil), w[weekDay][:]...)
+		}
+	}
+}
+
+var (
+	_ json.Marshaler   = WeightedHours{}
+	_ json.Unmarshaler = (*WeightedHours)(nil)
+	_ yaml.Marshaler   = WeightedHours{}
+	_ yaml.Unmarshaler = (*WeightedHours)(nil)
+	_ driver.Valuer    = WeightedHours{}
+	_ sql.Scanner      = (*WeightedHours)(nil)
+)
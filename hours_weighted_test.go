@@ -0,0 +1,183 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func testWeightedHours() WeightedHours {
+	w := NewWeightedHours(1)
+	w.SetHours(MustHoursByRanges("0-6"), 0.5)
+	w.SetHours(MustHoursByRanges("19-22"), 1.5)
+	w.SetHours(MustHoursByRanges("Sun"), 0)
+	return w
+}
+
+func TestWeightedHours_Weight(t *testing.T) {
+	w := testWeightedHours()
+	tests := []struct {
+		name   string
+		time   time.Time
+		weight float64
+	}{
+		{name: "night", time: time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), weight: 0.5},
+		{name: "day", time: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC), weight: 1},
+		{name: "evening", time: time.Date(2024, 1, 6, 21, 59, 0, 0, time.UTC), weight: 1.5},
+		{name: "after evening", time: time.Date(2024, 1, 6, 22, 0, 0, 0, time.UTC), weight: 1},
+		{name: "sunday", time: time.Date(2024, 1, 7, 20, 0, 0, 0, time.UTC), weight: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if weight := w.WeightTime(tt.time); weight != tt.weight {
+				t.Errorf("WeightTime() = %v, expected %v", weight, tt.weight)
+			}
+			if weight := w.Weight(tt.time.Weekday(), byte(tt.time.Hour())); weight != tt.weight {
+				t.Errorf("Weight() = %v, expected %v", weight, tt.weight)
+			}
+		})
+	}
+
+	if weight := w.Weight(time.Monday, 24); weight != 0 {
+		t.Errorf("Weight(24) = %v, expected 0", weight)
+	}
+	w.SetWeight(time.Monday, 24, 2)
+	if w != testWeightedHours() {
+		t.Error("SetWeight() out of range must be ignored")
+	}
+}
+
+func TestWeightedHours_Hours(t *testing.T) {
+	w := testWeightedHours()
+	tests := []struct {
+		threshold float64
+		hours     Hours
+	}{
+		{threshold: -1, hours: nil},
+		{threshold: 0, hours: MustHoursByRanges("Mon-Sat")},
+		{threshold: 0.5, hours: MustHoursByRanges("Mon-Sat 6-24")},
+		{threshold: 1, hours: MustHoursByRanges("Mon-Sat 19-22")},
+		{threshold: 1.5, hours: make(Hours, 24)},
+	}
+
+	for _, tt := range tests {
+		if h := w.Hours(tt.threshold); !h.Equal(tt.hours) || h.IsNoActive() != tt.hours.IsNoActive() {
+			t.Errorf("Hours(%v) = %v, expected %v", tt.threshold, h, tt.hours)
+		}
+	}
+
+	business := MustHoursByRanges("Mon-Fri 9-17")
+	weighted := WeightedHoursByHours(business, 1)
+	if h := weighted.Hours(0); !h.Equal(business) {
+		t.Errorf("WeightedHoursByHours().Hours() = %v, expected %v", h, business)
+	}
+	weighted = WeightedHoursByHours(nil, 1)
+	if h := weighted.Hours(0); !h.IsAllActive() {
+		t.Errorf("WeightedHoursByHours(nil).Hours() = %v, expected all active", h)
+	}
+}
+
+func TestWeightedHours_Combine(t *testing.T) {
+	w := testWeightedHours()
+	w2 := WeightedHoursByHours(MustHoursByRanges("Mon 0-12"), 2)
+
+	tests := []struct {
+		name    string
+		result  WeightedHours
+		weights [4]float64 // Mon 3h, Mon 12h, Mon 20h, Sun 3h
+	}{
+		{name: "mul", result: w.Mul(&w2), weights: [4]float64{1, 0, 0, 0}},
+		{name: "min", result: w.Min(&w2), weights: [4]float64{0.5, 0, 0, 0}},
+		{name: "max", result: w.Max(&w2), weights: [4]float64{2, 1, 1.5, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights := [4]float64{
+				tt.result.Weight(time.Monday, 3),
+				tt.result.Weight(time.Monday, 12),
+				tt.result.Weight(time.Monday, 20),
+				tt.result.Weight(time.Sunday, 3),
+			}
+			if weights != tt.weights {
+				t.Errorf("%s() = %v, expected %v", tt.name, weights, tt.weights)
+			}
+		})
+	}
+
+	if w != testWeightedHours() {
+		t.Error("combination must not change the source grid")
+	}
+}
+
+func TestWeightedHours_Codecs(t *testing.T) {
+	type item struct {
+		Weights WeightedHours `json:"weights" yaml:"weights"`
+	}
+	w := testWeightedHours()
+
+	data, err := json.Marshal(item{Weights: w})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), `"sun"`) || !strings.HasPrefix(string(data), `{"weights":{"mon":[0.5,`) {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+	var decoded item
+	if err = json.Unmarshal(data, &decoded); err != nil || decoded.Weights != w {
+		t.Errorf("json.Unmarshal() = %v, %v, expected %v", decoded.Weights, err, w)
+	}
+
+	if data, err = yaml.Marshal(item{Weights: w}); err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	decoded = item{}
+	if err = yaml.Unmarshal(data, &decoded); err != nil || decoded.Weights != w {
+		t.Errorf("yaml.Unmarshal(%s) = %v, %v, expected %v", data, decoded.Weights, err, w)
+	}
+
+	value, err := w.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	var scanned WeightedHours
+	if err = scanned.Scan(value); err != nil || scanned != w {
+		t.Errorf("Scan() = %v, %v, expected %v", scanned, err, w)
+	}
+	if err = scanned.Scan(nil); err != nil || scanned != (WeightedHours{}) {
+		t.Errorf("Scan(nil) = %v, %v, expected zero", scanned, err)
+	}
+	if err = scanned.Scan(42); err == nil {
+		t.Error("expected error of the unsupported type")
+	}
+
+	// The short days are completed with zeros
+	if scanned, err = WeightedHoursByJSON([]byte(`{"fri":[1,2]}`)); err != nil || scanned.Weight(time.Friday, 1) != 2 || scanned.Weight(time.Friday, 2) != 0 {
+		t.Errorf("WeightedHoursByJSON() = %v, %v", scanned, err)
+	}
+	if _, err = WeightedHoursByJSON([]byte(`{"fri":[` + strings.Repeat("1,", 24) + `1]}`)); err != ErrTooMuchWeightsForDecode {
+		t.Errorf("WeightedHoursByJSON() error = %v, expected %v", err, ErrTooMuchWeightsForDecode)
+	}
+}
+
+func Benchmark_WeightedHours(b *testing.B) {
+	var (
+		w   = testWeightedHours()
+		now = time.Now()
+	)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		var i = 0
+		for pb.Next() {
+			_ = w.Weight(time.Weekday(i%7), byte(i%24))
+			_ = w.WeightTime(now)
+			i++
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package hourstable
+
+import (
+	"encoding/xml"
+)
+
+// MarshalXML implements the functionality of xml.Marshaler interface.
+// Every day is the child element with the same name and format as in JSON
+// (<mon>*</mon>), the inactive days are omitted.
+func (h HoursObject) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var timetable timetableJSON
+	timetable.FromHours(Hours(h))
+	return e.EncodeElement(&timetable, start)
+}
+
+// UnmarshalXML implements the functionality of xml.Unmarshaler interface
+func (h *HoursObject) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var timetable timetableJSON
+	if err := d.DecodeElement(&timetable, &start); err != nil {
+		return err
+	}
+	*h = HoursObject(timetable.ToHours())
+	return nil
+}
+
+// MarshalXMLAttr implements the functionality of xml.MarshalerAttr interface
+func (h Hours) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: h.String()}, nil
+}
+
+// UnmarshalXMLAttr implements the functionality of xml.UnmarshalerAttr interface
+func (h *Hours) UnmarshalXMLAttr(attr xml.Attr) error {
+	newHours, err := HoursByString(attr.Value)
+	if err != nil {
+		return err
+	}
+	*h = newHours
+	return nil
+}
+
+var (
+	_ xml.Marshaler       = (HoursObject)(nil)
+	_ xml.Unmarshaler     = (*HoursObject)(nil)
+	_ xml.MarshalerAttr   = (Hours)(nil)
+	_ xml.UnmarshalerAttr = (*Hours)(nil)
+)
@@ -0,0 +1,120 @@
+package hourstable
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+func TestHoursObject_XML(t *testing.T) {
+	type item struct {
+		XMLName xml.Name    `xml:"item"`
+		Hours   HoursObject `xml:"hours"`
+	}
+	for _, tt := range hourstest.Tables() {
+		t.Run(tt.Name, func(t *testing.T) {
+			data, err := xml.Marshal(item{Hours: HoursObject(tt.Hours)})
+			if err != nil {
+				t.Fatalf("xml.Marshal() error = %v", err)
+			}
+			var decoded item
+			if err = xml.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("xml.Unmarshal(%s) error = %v", data, err)
+			}
+			if !decoded.Hours.Equal(tt.Hours) {
+				t.Errorf("xml.Unmarshal(%s) = %v, expected %v", data, decoded.Hours, tt.Hours)
+			}
+		})
+	}
+}
+
+func TestHoursObject_XMLElements(t *testing.T) {
+	tests := []struct {
+		name     string
+		hours    Hours
+		expected string
+	}{
+		{
+			name:     "star days",
+			hours:    nil,
+			expected: `<hours><mon>*</mon><tue>*</tue><wed>*</wed><thu>*</thu><fri>*</fri><sat>*</sat><sun>*</sun></hours>`,
+		},
+		{
+			name:     "no days",
+			hours:    make(Hours, 24),
+			expected: `<hours></hours>`,
+		},
+		{
+			name:  "star and hours days",
+			hours: MustHoursByRanges("Sat 10-12; Sun"),
+			expected: `<hours><sat>000000000011000000000000</sat>` +
+				`<sun>*</sun></hours>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := xml.Marshal(struct {
+				XMLName xml.Name    `xml:"hours"`
+				Hours   HoursObject `xml:"hours"`
+			}{Hours: HoursObject(tt.hours)})
+			if err != nil {
+				t.Fatalf("xml.Marshal() error = %v", err)
+			}
+			if res := strings.TrimSuffix(strings.TrimPrefix(string(data), "<hours>"), "</hours>"); res != tt.expected {
+				t.Errorf("xml.Marshal() = %s, expected %s", res, tt.expected)
+			}
+
+			var decoded HoursObject
+			if err = xml.Unmarshal([]byte(tt.expected), &decoded); err != nil {
+				t.Fatalf("xml.Unmarshal() error = %v", err)
+			}
+			if !decoded.Equal(tt.hours) {
+				t.Errorf("xml.Unmarshal() = %v, expected %v", decoded, tt.hours)
+			}
+		})
+	}
+
+	// Empty element is the inactive day as well as the empty string in JSON
+	var decoded HoursObject
+	if err := xml.Unmarshal([]byte(`<hours><mon>*</mon><tue></tue><wed/></hours>`), &decoded); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	if expected := MustHoursByRanges("Mon"); !decoded.Equal(expected) {
+		t.Errorf("xml.Unmarshal() = %v, expected %v", decoded, expected)
+	}
+}
+
+func TestHours_XMLAttr(t *testing.T) {
+	type campaign struct {
+		XMLName xml.Name `xml:"campaign"`
+		Hours   Hours    `xml:"hours,attr"`
+	}
+	for _, tt := range hourstest.Tables() {
+		h := Hours(tt.Hours)
+		data, err := xml.Marshal(campaign{Hours: h})
+		if err != nil {
+			t.Fatalf("xml.Marshal() error = %v", err)
+		}
+		if expected := `<campaign hours="` + h.String() + `"></campaign>`; string(data) != expected {
+			t.Errorf("xml.Marshal() = %s, expected %s", data, expected)
+		}
+		var decoded campaign
+		if err = xml.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("xml.Unmarshal() error = %v", err)
+		}
+		if !decoded.Hours.Equal(h) {
+			t.Errorf("xml.Unmarshal() = %v, expected %v", decoded.Hours, h)
+		}
+	}
+
+	var (
+		decoded  campaign
+		business = MustHoursByRanges("Mon-Fri 9-17")
+	)
+	if err := xml.Unmarshal([]byte(`<campaign hours="`+business.CompactString()+`"/>`), &decoded); err != nil || !decoded.Hours.Equal(business) {
+		t.Errorf("xml.Unmarshal() = %v, %v, expected %v", decoded.Hours, err, business)
+	}
+}
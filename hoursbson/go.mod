@@ -0,0 +1,10 @@
+module github.com/geniusrabbit/hourstable/hoursbson
+
+go 1.21
+
+require github.com/geniusrabbit/hourstable v0.0.0-20261017060307-bad43c304d9e
+
+require (
+	go.mongodb.org/mongo-driver v1.17.6
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,156 @@
+// Package hoursbson implements the BSON encoding of the hours tables
+// for go.mongodb.org/mongo-driver.
+//
+// Hours is stored as the string of the text format, BinaryHours as the binary
+// of the compact binary format and HoursObject as the embedded document with
+// mon..sun keys in the same format as JSON. Every type decodes all of these
+// representations, so the storage format could be changed without migration.
+package hoursbson
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+
+	"github.com/geniusrabbit/hourstable"
+)
+
+// BinarySubtype of the BSON binary with the hours (user defined)
+const BinarySubtype byte = 0x80
+
+// Hours is the hourstable.Hours stored as BSON string
+type Hours hourstable.Hours
+
+// BinaryHours is the hourstable.Hours stored as BSON binary
+type BinaryHours hourstable.Hours
+
+// HoursObject is the hourstable.HoursObject stored as BSON document with mon..sun keys
+type HoursObject hourstable.HoursObject
+
+// MarshalBSONValue implements bson.ValueMarshaler
+func (h Hours) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.TypeString, bsoncore.AppendString(nil, hourstable.Hours(h).String()), nil
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler
+func (h *Hours) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	newHours, err := decode(t, data)
+	if err != nil {
+		return err
+	}
+	*h = Hours(newHours)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler
+func (h BinaryHours) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	data, err := hourstable.Hours(h).MarshalBinary()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bson.TypeBinary, bsoncore.AppendBinary(nil, BinarySubtype, data), nil
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler
+func (h *BinaryHours) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	newHours, err := decode(t, data)
+	if err != nil {
+		return err
+	}
+	*h = BinaryHours(newHours)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler
+func (h HoursObject) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	hours := hourstable.Hours(h)
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for _, day := range weekDays {
+		if dayHours := hours.Day(day.weekDay); dayHours != hourstable.NoDayHours {
+			doc = bsoncore.AppendStringElement(doc, day.key, dayHours.String())
+		}
+	}
+	doc, err := bsoncore.AppendDocumentEnd(doc, idx)
+	return bson.TypeEmbeddedDocument, doc, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler
+func (h *HoursObject) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	newHours, err := decode(t, data)
+	if err != nil {
+		return err
+	}
+	*h = HoursObject(newHours)
+	return nil
+}
+
+type timetable struct {
+	Monday    string `bson:"mon,omitempty"`
+	Tuesday   string `bson:"tue,omitempty"`
+	Wednesday string `bson:"wed,omitempty"`
+	Thursday  string `bson:"thu,omitempty"`
+	Friday    string `bson:"fri,omitempty"`
+	Saturday  string `bson:"sat,omitempty"`
+	Sunday    string `bson:"sun,omitempty"`
+}
+
+var weekDays = []struct {
+	key     string
+	weekDay time.Weekday
+	hours   func(*timetable) string
+}{
+	{"mon", time.Monday, func(tt *timetable) string { return tt.Monday }},
+	{"tue", time.Tuesday, func(tt *timetable) string { return tt.Tuesday }},
+	{"wed", time.Wednesday, func(tt *timetable) string { return tt.Wednesday }},
+	{"thu", time.Thursday, func(tt *timetable) string { return tt.Thursday }},
+	{"fri", time.Friday, func(tt *timetable) string { return tt.Friday }},
+	{"sat", time.Saturday, func(tt *timetable) string { return tt.Saturday }},
+	{"sun", time.Sunday, func(tt *timetable) string { return tt.Sunday }},
+}
+
+func decode(t bsontype.Type, data []byte) (hourstable.Hours, error) {
+	value := bsoncore.Value{Type: t, Data: data}
+	switch t {
+	case bson.TypeNull, bson.TypeUndefined:
+		return nil, nil
+	case bson.TypeString:
+		s, ok := value.StringValueOK()
+		if !ok {
+			return nil, fmt.Errorf("[hoursbson] invalid string value")
+		}
+		return hourstable.HoursByString(s)
+	case bson.TypeBinary:
+		_, binary, ok := value.BinaryOK()
+		if !ok {
+			return nil, fmt.Errorf("[hoursbson] invalid binary value")
+		}
+		return hourstable.HoursByBinary(binary)
+	case bson.TypeEmbeddedDocument:
+		var tt timetable
+		if err := bson.Unmarshal(data, &tt); err != nil {
+			return nil, err
+		}
+		hours := make(hourstable.Hours, 24)
+		for _, day := range weekDays {
+			dayHours, err := hourstable.DayHoursByString(day.hours(&tt))
+			if err != nil {
+				return nil, err
+			}
+			hours.SetDay(day.weekDay, dayHours)
+		}
+		return hours, nil
+	}
+	return nil, fmt.Errorf("[hoursbson] unsupported BSON type %s", t)
+}
+
+var (
+	_ bson.ValueMarshaler   = Hours(nil)
+	_ bson.ValueUnmarshaler = (*Hours)(nil)
+	_ bson.ValueMarshaler   = BinaryHours(nil)
+	_ bson.ValueUnmarshaler = (*BinaryHours)(nil)
+	_ bson.ValueMarshaler   = HoursObject(nil)
+	_ bson.ValueUnmarshaler = (*HoursObject)(nil)
+)
@@ -0,0 +1,112 @@
+package hoursbson
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/geniusrabbit/hourstable"
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+type campaign struct {
+	Hours  Hours       `bson:"hours"`
+	Binary BinaryHours `bson:"binary"`
+	Object HoursObject `bson:"object"`
+}
+
+func TestBSON(t *testing.T) {
+	for _, tt := range hourstest.Tables() {
+		t.Run(tt.Name, func(t *testing.T) {
+			data, err := bson.Marshal(campaign{
+				Hours:  Hours(tt.Hours),
+				Binary: BinaryHours(tt.Hours),
+				Object: HoursObject(tt.Hours),
+			})
+			if err != nil {
+				t.Fatalf("bson.Marshal() error = %v", err)
+			}
+
+			raw := bson.Raw(data)
+			if tp := raw.Lookup("hours").Type; tp != bson.TypeString {
+				t.Errorf("hours type = %s, expected string", tp)
+			}
+			if subtype, _ := raw.Lookup("binary").Binary(); subtype != BinarySubtype {
+				t.Errorf("binary subtype = %#x, expected %#x", subtype, BinarySubtype)
+			}
+			if tp := raw.Lookup("object").Type; tp != bson.TypeEmbeddedDocument {
+				t.Errorf("object type = %s, expected document", tp)
+			}
+
+			var decoded campaign
+			if err = bson.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("bson.Unmarshal() error = %v", err)
+			}
+			for _, h := range []hourstable.Hours{
+				hourstable.Hours(decoded.Hours),
+				hourstable.Hours(decoded.Binary),
+				hourstable.Hours(decoded.Object),
+			} {
+				if !h.Equal(tt.Hours) {
+					t.Errorf("bson.Unmarshal() = %v, expected %v", h, tt.Hours)
+				}
+			}
+		})
+	}
+}
+
+func TestBSON_Legacy(t *testing.T) {
+	business := hourstable.MustHoursByRanges("Mon-Fri 9-17")
+	day := business.Day(1).String()
+
+	// Legacy documents store the string and the document in any of the fields
+	data, err := bson.Marshal(bson.M{
+		"hours":  bson.M{"mon": day, "tue": day, "wed": day, "thu": day, "fri": day},
+		"binary": business.String(),
+		"object": business.String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded campaign
+	if err = bson.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("bson.Unmarshal() error = %v", err)
+	}
+	for _, h := range []hourstable.Hours{
+		hourstable.Hours(decoded.Hours),
+		hourstable.Hours(decoded.Binary),
+		hourstable.Hours(decoded.Object),
+	} {
+		if !h.Equal(business) {
+			t.Errorf("bson.Unmarshal() = %v, expected %v", h, business)
+		}
+	}
+
+	data, _ = bson.Marshal(bson.M{"object": bson.M{"mon": "*"}, "hours": nil})
+	if err = bson.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("bson.Unmarshal() error = %v", err)
+	}
+	if expected := hourstable.MustHoursByRanges("Mon"); !hourstable.Hours(decoded.Object).Equal(expected) {
+		t.Errorf("bson.Unmarshal() = %v, expected %v", decoded.Object, expected)
+	}
+
+	// The binary of the generic subtype is accepted as well
+	binary, _ := business.MarshalBinary()
+	data, _ = bson.Marshal(bson.M{"binary": primitive.Binary{Data: binary}})
+	if err = bson.Unmarshal(data, &decoded); err != nil || !hourstable.Hours(decoded.Binary).Equal(business) {
+		t.Errorf("bson.Unmarshal() = %v, %v, expected %v", decoded.Binary, err, business)
+	}
+
+	for _, value := range []any{
+		42,
+		primitive.Binary{Subtype: BinarySubtype, Data: binary[:20]},
+		primitive.Binary{Subtype: BinarySubtype, Data: []byte{0x20}},
+		bson.M{"mon": day + "0"},
+	} {
+		data, _ = bson.Marshal(bson.M{"hours": value})
+		if err = bson.Unmarshal(data, &decoded); err == nil {
+			t.Errorf("bson.Unmarshal(%v) expected error", value)
+		}
+	}
+}
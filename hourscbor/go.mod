@@ -0,0 +1,13 @@
+module github.com/geniusrabbit/hourstable/hourscbor
+
+go 1.21
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.2
+	github.com/geniusrabbit/hourstable v0.0.0-20261017060307-bad43c304d9e
+)
+
+require (
+	github.com/x448/float16 v0.8.4 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
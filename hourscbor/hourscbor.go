@@ -0,0 +1,95 @@
+// Package hourscbor implements the CBOR encoding of the hours tables
+// for github.com/fxamacker/cbor/v2 in the compact binary format.
+package hourscbor
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/geniusrabbit/hourstable"
+)
+
+// CBOR major types of the supported data items
+const (
+	majorTypeByteString = 2
+	majorTypeTextString = 3
+	simpleValueNull     = 0xf6
+	simpleValueUndef    = 0xf7
+)
+
+// Hours is the hourstable.Hours encoded as CBOR byte string of the binary format
+type Hours hourstable.Hours
+
+// HoursObject is the hourstable.HoursObject encoded as CBOR byte string of the binary format
+type HoursObject hourstable.HoursObject
+
+// MarshalCBOR implements cbor.Marshaler
+func (h Hours) MarshalCBOR() ([]byte, error) {
+	return marshal(hourstable.Hours(h))
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// The text string of the text format is accepted as well.
+func (h *Hours) UnmarshalCBOR(data []byte) error {
+	newHours, err := unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*h = Hours(newHours)
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler
+func (h HoursObject) MarshalCBOR() ([]byte, error) {
+	return marshal(hourstable.Hours(h))
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// The text string of the text format is accepted as well.
+func (h *HoursObject) UnmarshalCBOR(data []byte) error {
+	newHours, err := unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*h = HoursObject(newHours)
+	return nil
+}
+
+func marshal(h hourstable.Hours) ([]byte, error) {
+	data, err := h.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(data)
+}
+
+func unmarshal(data []byte) (hourstable.Hours, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("[hourscbor] empty data")
+	}
+	switch {
+	case data[0] == simpleValueNull || data[0] == simpleValueUndef:
+		return nil, nil
+	case data[0]>>5 == majorTypeByteString:
+		var binary []byte
+		if err := cbor.Unmarshal(data, &binary); err != nil {
+			return nil, err
+		}
+		return hourstable.HoursByBinary(binary)
+	case data[0]>>5 == majorTypeTextString:
+		var s string
+		if err := cbor.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		return hourstable.HoursByString(s)
+	}
+	return nil, fmt.Errorf("[hourscbor] unsupported CBOR data item %#x", data[0])
+}
+
+var (
+	_ cbor.Marshaler   = Hours(nil)
+	_ cbor.Unmarshaler = (*Hours)(nil)
+	_ cbor.Marshaler   = HoursObject(nil)
+	_ cbor.Unmarshaler = (*HoursObject)(nil)
+)
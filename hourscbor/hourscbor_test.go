@@ -0,0 +1,81 @@
+package hourscbor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/geniusrabbit/hourstable"
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+func TestCBOR(t *testing.T) {
+	type target struct {
+		Hours  Hours       `cbor:"hours"`
+		Object HoursObject `cbor:"object"`
+	}
+
+	for _, tt := range hourstest.Tables() {
+		t.Run(tt.Name, func(t *testing.T) {
+			binary, _ := hourstable.Hours(tt.Hours).MarshalBinary()
+			data, err := cbor.Marshal(Hours(tt.Hours))
+			if err != nil {
+				t.Fatalf("cbor.Marshal() error = %v", err)
+			}
+			// byte string of the core binary format, the length is in the head
+			if expected := append([]byte{0x40 | byte(len(binary))}, binary...); !bytes.Equal(data, expected) {
+				t.Errorf("cbor.Marshal() = %x, expected %x", data, expected)
+			}
+
+			data, err = cbor.Marshal(target{Hours: Hours(tt.Hours), Object: HoursObject(tt.Hours)})
+			if err != nil {
+				t.Fatalf("cbor.Marshal() error = %v", err)
+			}
+			var decoded target
+			if err = cbor.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("cbor.Unmarshal() error = %v", err)
+			}
+			for _, h := range []hourstable.Hours{hourstable.Hours(decoded.Hours), hourstable.Hours(decoded.Object)} {
+				if !h.Equal(tt.Hours) {
+					t.Errorf("cbor.Unmarshal() = %v, expected %v", h, tt.Hours)
+				}
+			}
+		})
+	}
+}
+
+func TestCBOR_Legacy(t *testing.T) {
+	business := hourstable.MustHoursByRanges("Mon-Fri 9-17")
+
+	data, err := cbor.Marshal(map[string]any{"hours": business.String(), "object": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Hours  Hours       `cbor:"hours"`
+		Object HoursObject `cbor:"object"`
+	}
+	if err = cbor.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("cbor.Unmarshal() error = %v", err)
+	}
+	if !hourstable.Hours(decoded.Hours).Equal(business) {
+		t.Errorf("cbor.Unmarshal() = %v, expected %v", decoded.Hours, business)
+	}
+	if decoded.Object != nil {
+		t.Errorf("cbor.Unmarshal() = %v, expected nil", decoded.Object)
+	}
+
+	// The undefined value is the missing table as well as null
+	var h Hours = Hours(business)
+	if err = cbor.Unmarshal([]byte{0xf7}, &h); err != nil || h != nil {
+		t.Errorf("cbor.Unmarshal(undefined) = %v, %v, expected nil", h, err)
+	}
+
+	for _, value := range []any{42, []byte{0x10, 0x00}, make([]byte, 20), []byte{0x20}} {
+		data, _ = cbor.Marshal(value)
+		if err = cbor.Unmarshal(data, &h); err == nil {
+			t.Errorf("cbor.Unmarshal(%x) expected error", data)
+		}
+	}
+}
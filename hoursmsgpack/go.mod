@@ -0,0 +1,13 @@
+module github.com/geniusrabbit/hourstable/hoursmsgpack
+
+go 1.21
+
+require (
+	github.com/geniusrabbit/hourstable v0.0.0-20261017060307-bad43c304d9e
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
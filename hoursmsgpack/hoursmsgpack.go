@@ -0,0 +1,101 @@
+// Package hoursmsgpack implements the MessagePack encoding of the hours tables
+// for github.com/vmihailenco/msgpack/v5 in the compact binary format.
+package hoursmsgpack
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+
+	"github.com/geniusrabbit/hourstable"
+)
+
+func init() {
+	// msgpack writes nil slices as nil before the custom encoders,
+	// the registered encoders receive them, so all active hours keep the binary marker
+	msgpack.Register(Hours(nil), encodeValue, nil)
+	msgpack.Register(HoursObject(nil), encodeValue, nil)
+}
+
+// Hours is the hourstable.Hours encoded as MessagePack bin of the binary format
+type Hours hourstable.Hours
+
+// HoursObject is the hourstable.HoursObject encoded as MessagePack bin of the binary format
+type HoursObject hourstable.HoursObject
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (h Hours) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return encode(enc, hourstable.Hours(h))
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+// The string of the text format and nil of the legacy all active hours are accepted as well.
+func (h *Hours) DecodeMsgpack(dec *msgpack.Decoder) error {
+	newHours, err := decode(dec)
+	if err != nil {
+		return err
+	}
+	*h = Hours(newHours)
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (h HoursObject) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return encode(enc, hourstable.Hours(h))
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+// The string of the text format and nil of the legacy all active hours are accepted as well.
+func (h *HoursObject) DecodeMsgpack(dec *msgpack.Decoder) error {
+	newHours, err := decode(dec)
+	if err != nil {
+		return err
+	}
+	*h = HoursObject(newHours)
+	return nil
+}
+
+func encodeValue(enc *msgpack.Encoder, v reflect.Value) error {
+	return encode(enc, hourstable.Hours(v.Bytes()))
+}
+
+func encode(enc *msgpack.Encoder, h hourstable.Hours) error {
+	data, err := h.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return enc.EncodeBytes(data)
+}
+
+func decode(dec *msgpack.Decoder) (hourstable.Hours, error) {
+	code, err := dec.PeekCode()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case code == msgpcode.Nil:
+		return nil, dec.DecodeNil()
+	case msgpcode.IsBin(code):
+		data, err := dec.DecodeBytes()
+		if err != nil {
+			return nil, err
+		}
+		return hourstable.HoursByBinary(data)
+	case msgpcode.IsString(code):
+		s, err := dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+		return hourstable.HoursByString(s)
+	}
+	return nil, fmt.Errorf("[hoursmsgpack] unsupported msgpack code %#x", code)
+}
+
+var (
+	_ msgpack.CustomEncoder = Hours(nil)
+	_ msgpack.CustomDecoder = (*Hours)(nil)
+	_ msgpack.CustomEncoder = HoursObject(nil)
+	_ msgpack.CustomDecoder = (*HoursObject)(nil)
+)
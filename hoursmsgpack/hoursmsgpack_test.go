@@ -0,0 +1,93 @@
+package hoursmsgpack
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+
+	"github.com/geniusrabbit/hourstable"
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+func TestMsgpack(t *testing.T) {
+	type target struct {
+		Hours  Hours       `msgpack:"hours"`
+		Object HoursObject `msgpack:"object"`
+	}
+
+	for _, tt := range hourstest.Tables() {
+		t.Run(tt.Name, func(t *testing.T) {
+			binary, _ := hourstable.Hours(tt.Hours).MarshalBinary()
+			data, err := msgpack.Marshal(Hours(tt.Hours))
+			if err != nil {
+				t.Fatalf("msgpack.Marshal() error = %v", err)
+			}
+			// bin 8 of the core binary format
+			if expected := append([]byte{msgpcode.Bin8, byte(len(binary))}, binary...); !bytes.Equal(data, expected) {
+				t.Errorf("msgpack.Marshal() = %x, expected %x", data, expected)
+			}
+
+			data, err = msgpack.Marshal(target{Hours: Hours(tt.Hours), Object: HoursObject(tt.Hours)})
+			if err != nil {
+				t.Fatalf("msgpack.Marshal() error = %v", err)
+			}
+			var decoded target
+			if err = msgpack.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("msgpack.Unmarshal() error = %v", err)
+			}
+			for _, h := range []hourstable.Hours{hourstable.Hours(decoded.Hours), hourstable.Hours(decoded.Object)} {
+				if !h.Equal(tt.Hours) {
+					t.Errorf("msgpack.Unmarshal() = %v, expected %v", h, tt.Hours)
+				}
+			}
+		})
+	}
+}
+
+func TestMsgpack_AllActive(t *testing.T) {
+	data, err := msgpack.Marshal(struct {
+		Hours  Hours       `msgpack:"hours"`
+		Object HoursObject `msgpack:"object"`
+		Any    any         `msgpack:"any"`
+	}{Any: Hours(nil)})
+	if err != nil {
+		t.Fatalf("msgpack.Marshal() error = %v", err)
+	}
+	// Every nil table is the bin of the all active marker, not the msgpack nil
+	marker := []byte{msgpcode.Bin8, 1, 0x10}
+	if n := bytes.Count(data, marker); n != 3 || bytes.IndexByte(data, msgpcode.Nil) >= 0 {
+		t.Errorf("msgpack.Marshal() = %x, expected 3 markers %x", data, marker)
+	}
+}
+
+func TestMsgpack_Legacy(t *testing.T) {
+	business := hourstable.MustHoursByRanges("Mon-Fri 9-17")
+
+	data, err := msgpack.Marshal(map[string]any{"hours": business.String(), "object": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Hours  Hours       `msgpack:"hours"`
+		Object HoursObject `msgpack:"object"`
+	}
+	if err = msgpack.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("msgpack.Unmarshal() error = %v", err)
+	}
+	if !hourstable.Hours(decoded.Hours).Equal(business) {
+		t.Errorf("msgpack.Unmarshal() = %v, expected %v", decoded.Hours, business)
+	}
+	if decoded.Object != nil {
+		t.Errorf("msgpack.Unmarshal() = %v, expected nil", decoded.Object)
+	}
+
+	var h Hours
+	for _, value := range []any{42, []byte{0x10, 0x00}, make([]byte, 20), []byte{0x20}} {
+		data, _ = msgpack.Marshal(value)
+		if err = msgpack.Unmarshal(data, &h); err == nil {
+			t.Errorf("msgpack.Unmarshal(%x) expected error", data)
+		}
+	}
+}
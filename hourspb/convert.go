@@ -0,0 +1,128 @@
+// Package hourspb contains the Protocol Buffers messages of the weekly hours table
+// and the conversion helpers to and from hourstable.Hours.
+package hourspb
+
+//go:generate protoc --proto_path=.. --go_out=.. --go_opt=paths=source_relative hourspb/hours.proto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/geniusrabbit/hourstable"
+)
+
+// PackedSize of the packed table of 7*24 bits
+const PackedSize = 7 * 24 / 8
+
+// Validation errors of the messages
+var (
+	ErrInvalidPackedHours = errors.New("[hourspb] invalid packed hours table, expected 21 bytes")
+	ErrInvalidDayHours    = errors.New("[hourspb] invalid day hours, only 24 lower bits are allowed")
+	ErrEmptyWeekHours     = errors.New("[hourspb] empty days table")
+)
+
+// FromHours returns the message with the packed table of the hours,
+// all active hours are encoded as the message without the table.
+// The packed table is the binary format of hourstable.Hours.
+func FromHours(h hourstable.Hours) *Hours {
+	switch {
+	case h.IsAllActive():
+		return &Hours{}
+	case h.IsNoActive():
+		// The binary format has the one byte marker of it
+		return &Hours{Table: &Hours_Packed{Packed: make([]byte, PackedSize)}}
+	}
+	packed, _ := h.AppendBinary(make([]byte, 0, PackedSize))
+	return &Hours{Table: &Hours_Packed{Packed: packed}}
+}
+
+// FromHoursObject returns the message with the per day table of the hours,
+// all active hours are encoded as the message without the table
+func FromHoursObject(h hourstable.HoursObject) *Hours {
+	if h.IsAllActive() {
+		return &Hours{}
+	}
+	hours := hourstable.Hours(h)
+	return &Hours{Table: &Hours_Days{Days: &WeekHours{
+		Sun: uint32(hours.Day(time.Sunday)),
+		Mon: uint32(hours.Day(time.Monday)),
+		Tue: uint32(hours.Day(time.Tuesday)),
+		Wed: uint32(hours.Day(time.Wednesday)),
+		Thu: uint32(hours.Day(time.Thursday)),
+		Fri: uint32(hours.Day(time.Friday)),
+		Sat: uint32(hours.Day(time.Saturday)),
+	}}}
+}
+
+// Validate the message table
+func (x *Hours) Validate() error {
+	switch table := x.GetTable().(type) {
+	case *Hours_Packed:
+		if len(table.Packed) != PackedSize {
+			return ErrInvalidPackedHours
+		}
+	case *Hours_Days:
+		if table.Days == nil {
+			return ErrEmptyWeekHours
+		}
+		for _, day := range table.Days.days() {
+			if day.hours&^uint32(hourstable.AllDayHours) != 0 {
+				return fmt.Errorf("%w: %s", ErrInvalidDayHours, day.weekDay)
+			}
+		}
+	}
+	return nil
+}
+
+// ToHours converts the message into the hours table,
+// the nil message and the message without the table are all active hours
+func (x *Hours) ToHours() (hourstable.Hours, error) {
+	if err := x.Validate(); err != nil {
+		return nil, err
+	}
+	var (
+		h   hourstable.Hours
+		err error
+	)
+	switch table := x.GetTable().(type) {
+	case *Hours_Packed:
+		if h, err = hourstable.HoursByBinary(table.Packed); err != nil {
+			return nil, err
+		}
+	case *Hours_Days:
+		h = make(hourstable.Hours, 24)
+		for _, day := range table.Days.days() {
+			h.SetDay(day.weekDay, hourstable.DayHours(day.hours))
+		}
+	default:
+		return nil, nil
+	}
+	if h.IsAllActive() {
+		return nil, nil
+	}
+	return h, nil
+}
+
+// ToHoursObject converts the message into the hours table, see ToHours
+func (x *Hours) ToHoursObject() (hourstable.HoursObject, error) {
+	h, err := x.ToHours()
+	return hourstable.HoursObject(h), err
+}
+
+type weekDayHours struct {
+	weekDay time.Weekday
+	hours   uint32
+}
+
+func (x *WeekHours) days() [7]weekDayHours {
+	return [7]weekDayHours{
+		{time.Sunday, x.GetSun()},
+		{time.Monday, x.GetMon()},
+		{time.Tuesday, x.GetTue()},
+		{time.Wednesday, x.GetWed()},
+		{time.Thursday, x.GetThu()},
+		{time.Friday, x.GetFri()},
+		{time.Saturday, x.GetSat()},
+	}
+}
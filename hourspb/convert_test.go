@@ -0,0 +1,85 @@
+package hourspb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geniusrabbit/hourstable"
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+func TestConvert(t *testing.T) {
+	for _, tt := range hourstest.Tables() {
+		t.Run(tt.Name, func(t *testing.T) {
+			hours := hourstable.Hours(tt.Hours)
+			for _, msg := range []*Hours{FromHours(hours), FromHoursObject(hourstable.HoursObject(hours))} {
+				data, err := proto.Marshal(msg)
+				if err != nil {
+					t.Fatalf("proto.Marshal() error = %v", err)
+				}
+				var decoded Hours
+				if err = proto.Unmarshal(data, &decoded); err != nil {
+					t.Fatalf("proto.Unmarshal() error = %v", err)
+				}
+				h, err := decoded.ToHours()
+				if err != nil {
+					t.Fatalf("ToHours() error = %v", err)
+				}
+				if !h.Equal(hours) {
+					t.Errorf("ToHours() = %v, expected %v", h, hours)
+				}
+				o, err := decoded.ToHoursObject()
+				if err != nil || !o.Equal(hours) {
+					t.Errorf("ToHoursObject() = %v, %v, expected %v", o, err, hours)
+				}
+			}
+		})
+	}
+
+	// The packed table is the same as the binary format of the table
+	business := hourstable.MustHoursByRanges("Mon-Fri 9-17")
+	binary, _ := business.MarshalBinary()
+	if packed := FromHours(business).GetPacked(); string(packed) != string(binary) {
+		t.Errorf("FromHours() packed = %x, expected %x", packed, binary)
+	}
+
+	// The garbage bits and the missing hours of the short table are inactive
+	short := hourstable.Hours{0x81, 0xc0}
+	if h, err := FromHours(short).ToHours(); err != nil || !h.Equal(hourstable.MustHoursByRanges("Sun 0-1; Sat 1-2")) {
+		t.Errorf("ToHours() of the short table = %v, %v", h, err)
+	}
+	full := &Hours{Table: &Hours_Packed{Packed: bytes.Repeat([]byte{0xff}, PackedSize)}}
+	if h, err := full.ToHours(); err != nil || h != nil {
+		t.Errorf("ToHours() of the full table = %v, %v, expected nil", h, err)
+	}
+}
+
+func TestHours_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *Hours
+		err  error
+	}{
+		{name: "nil", msg: nil},
+		{name: "empty", msg: &Hours{}},
+		{name: "short packed", msg: &Hours{Table: &Hours_Packed{Packed: make([]byte, 20)}}, err: ErrInvalidPackedHours},
+		{name: "long packed", msg: &Hours{Table: &Hours_Packed{Packed: make([]byte, 22)}}, err: ErrInvalidPackedHours},
+		{name: "empty days", msg: &Hours{Table: &Hours_Days{}}, err: ErrEmptyWeekHours},
+		{name: "wide day", msg: &Hours{Table: &Hours_Days{Days: &WeekHours{Wed: 1 << 24}}}, err: ErrInvalidDayHours},
+		{name: "valid days", msg: &Hours{Table: &Hours_Days{Days: &WeekHours{Wed: 1<<24 - 1}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.msg.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() error = %v, expected %v", err, tt.err)
+			}
+			if _, err := tt.msg.ToHours(); !errors.Is(err, tt.err) {
+				t.Errorf("ToHours() error = %v, expected %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/geniusrabbit/hourstable/hourspb
+
+go 1.23
+
+require (
+	github.com/geniusrabbit/hourstable v0.0.0-20261017060307-bad43c304d9e
+	google.golang.org/protobuf v1.36.11
+)
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: hourspb/hours.proto
+
+package hourspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Hours is the weekly hours table.
+// The message without the table means that all hours are active.
+type Hours struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Table:
+	//
+	//	*Hours_Packed
+	//	*Hours_Days
+	Table         isHours_Table `protobuf_oneof:"table"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hours) Reset() {
+	*x = Hours{}
+	mi := &file_hourspb_hours_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hours) ProtoMessage() {}
+
+func (x *Hours) ProtoReflect() protoreflect.Message {
+	mi := &file_hourspb_hours_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hours.ProtoReflect.Descriptor instead.
+func (*Hours) Descriptor() ([]byte, []int) {
+	return file_hourspb_hours_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Hours) GetTable() isHours_Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *Hours) GetPacked() []byte {
+	if x != nil {
+		if x, ok := x.Table.(*Hours_Packed); ok {
+			return x.Packed
+		}
+	}
+	return nil
+}
+
+func (x *Hours) GetDays() *WeekHours {
+	if x != nil {
+		if x, ok := x.Table.(*Hours_Days); ok {
+			return x.Days
+		}
+	}
+	return nil
+}
+
+type isHours_Table interface {
+	isHours_Table()
+}
+
+type Hours_Packed struct {
+	// Packed table of 7*24 bits (21 bytes), bit N is the hour N of the week
+	// started from Sunday 0h, bits are numbered from the lowest bit of the first byte
+	Packed []byte `protobuf:"bytes,1,opt,name=packed,proto3,oneof"`
+}
+
+type Hours_Days struct {
+	// Table of the hours per day
+	Days *WeekHours `protobuf:"bytes,2,opt,name=days,proto3,oneof"`
+}
+
+func (*Hours_Packed) isHours_Table() {}
+
+func (*Hours_Days) isHours_Table() {}
+
+// WeekHours is the table of the active hours of every day of the week,
+// bit N of the day is the hour N, only the lower 24 bits are used
+type WeekHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sun           uint32                 `protobuf:"varint,1,opt,name=sun,proto3" json:"sun,omitempty"`
+	Mon           uint32                 `protobuf:"varint,2,opt,name=mon,proto3" json:"mon,omitempty"`
+	Tue           uint32                 `protobuf:"varint,3,opt,name=tue,proto3" json:"tue,omitempty"`
+	Wed           uint32                 `protobuf:"varint,4,opt,name=wed,proto3" json:"wed,omitempty"`
+	Thu           uint32                 `protobuf:"varint,5,opt,name=thu,proto3" json:"thu,omitempty"`
+	Fri           uint32                 `protobuf:"varint,6,opt,name=fri,proto3" json:"fri,omitempty"`
+	Sat           uint32                 `protobuf:"varint,7,opt,name=sat,proto3" json:"sat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeekHours) Reset() {
+	*x = WeekHours{}
+	mi := &file_hourspb_hours_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeekHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeekHours) ProtoMessage() {}
+
+func (x *WeekHours) ProtoReflect() protoreflect.Message {
+	mi := &file_hourspb_hours_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeekHours.ProtoReflect.Descriptor instead.
+func (*WeekHours) Descriptor() ([]byte, []int) {
+	return file_hourspb_hours_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WeekHours) GetSun() uint32 {
+	if x != nil {
+		return x.Sun
+	}
+	return 0
+}
+
+func (x *WeekHours) GetMon() uint32 {
+	if x != nil {
+		return x.Mon
+	}
+	return 0
+}
+
+func (x *WeekHours) GetTue() uint32 {
+	if x != nil {
+		return x.Tue
+	}
+	return 0
+}
+
+func (x *WeekHours) GetWed() uint32 {
+	if x != nil {
+		return x.Wed
+	}
+	return 0
+}
+
+func (x *WeekHours) GetThu() uint32 {
+	if x != nil {
+		return x.Thu
+	}
+	return 0
+}
+
+func (x *WeekHours) GetFri() uint32 {
+	if x != nil {
+		return x.Fri
+	}
+	return 0
+}
+
+func (x *WeekHours) GetSat() uint32 {
+	if x != nil {
+		return x.Sat
+	}
+	return 0
+}
+
+var File_hourspb_hours_proto protoreflect.FileDescriptor
+
+const file_hourspb_hours_proto_rawDesc = "" +
+	"\n" +
+	"\x13hourspb/hours.proto\x12\rhourstable.v1\"Z\n" +
+	"\x05Hours\x12\x18\n" +
+	"\x06packed\x18\x01 \x01(\fH\x00R\x06packed\x12.\n" +
+	"\x04days\x18\x02 \x01(\v2\x18.hourstable.v1.WeekHoursH\x00R\x04daysB\a\n" +
+	"\x05table\"\x89\x01\n" +
+	"\tWeekHours\x12\x10\n" +
+	"\x03sun\x18\x01 \x01(\rR\x03sun\x12\x10\n" +
+	"\x03mon\x18\x02 \x01(\rR\x03mon\x12\x10\n" +
+	"\x03tue\x18\x03 \x01(\rR\x03tue\x12\x10\n" +
+	"\x03wed\x18\x04 \x01(\rR\x03wed\x12\x10\n" +
+	"\x03thu\x18\x05 \x01(\rR\x03thu\x12\x10\n" +
+	"\x03fri\x18\x06 \x01(\rR\x03fri\x12\x10\n" +
+	"\x03sat\x18\a \x01(\rR\x03satB,Z*github.com/geniusrabbit/hourstable/hourspbb\x06proto3"
+
+var (
+	file_hourspb_hours_proto_rawDescOnce sync.Once
+	file_hourspb_hours_proto_rawDescData []byte
+)
+
+func file_hourspb_hours_proto_rawDescGZIP() []byte {
+	file_hourspb_hours_proto_rawDescOnce.Do(func() {
+		file_hourspb_hours_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hourspb_hours_proto_rawDesc), len(file_hourspb_hours_proto_rawDesc)))
+	})
+	return file_hourspb_hours_proto_rawDescData
+}
+
+var file_hourspb_hours_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_hourspb_hours_proto_goTypes = []any{
+	(*Hours)(nil),     // 0: hourstable.v1.Hours
+	(*WeekHours)(nil), // 1: hourstable.v1.WeekHours
+}
+var file_hourspb_hours_proto_depIdxs = []int32{
+	1, // 0: hourstable.v1.Hours.days:type_name -> hourstable.v1.WeekHours
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_hourspb_hours_proto_init() }
+func file_hourspb_hours_proto_init() {
+	if File_hourspb_hours_proto != nil {
+		return
+	}
+	file_hourspb_hours_proto_msgTypes[0].OneofWrappers = []any{
+		(*Hours_Packed)(nil),
+		(*Hours_Days)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hourspb_hours_proto_rawDesc), len(file_hourspb_hours_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_hourspb_hours_proto_goTypes,
+		DependencyIndexes: file_hourspb_hours_proto_depIdxs,
+		MessageInfos:      file_hourspb_hours_proto_msgTypes,
+	}.Build()
+	File_hourspb_hours_proto = out.File
+	file_hourspb_hours_proto_goTypes = nil
+	file_hourspb_hours_proto_depIdxs = nil
+}
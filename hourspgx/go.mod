@@ -0,0 +1,10 @@
+module github.com/geniusrabbit/hourstable/hourspgx
+
+go 1.25.0
+
+require (
+	github.com/geniusrabbit/hourstable v0.0.0-20261017060307-bad43c304d9e
+	github.com/jackc/pgx/v5 v5.10.0
+)
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
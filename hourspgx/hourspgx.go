@@ -0,0 +1,158 @@
+// Package hourspgx implements the Postgres types of the hours tables for github.com/jackc/pgx/v5.
+//
+// Hours is stored in bit(168) or varbit columns as the bit string in the same
+// order as the text format (bit 1 is Sunday 0h), so the table could be queried
+// with the bitwise SQL operators. The bytea columns use the compact binary format
+// and the text columns the text format. HoursObject is stored in jsonb (json) columns.
+// The text and binary protocols are supported by the pgx codecs.
+package hourspgx
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/geniusrabbit/hourstable"
+)
+
+// BitsLen of the bit string of the hours table
+const BitsLen = 7 * 24
+
+// allActiveHours is the not nil table of all active hours
+var allActiveHours = Hours(bytes.Repeat([]byte{0x7f}, 24))
+
+// Hours is the hourstable.Hours for bit(168), varbit, bytea and text columns.
+// The nil table is all active hours, it's written as the full table, not NULL.
+type Hours hourstable.Hours
+
+// HoursObject is the hourstable.HoursObject for jsonb and json columns
+type HoursObject hourstable.HoursObject
+
+// Register the default Postgres types of the hours in the type map,
+// the default types are used when the type of the parameter is unknown
+func Register(m *pgtype.Map) {
+	m.RegisterDefaultPgType(Hours(nil), "varbit")
+	m.RegisterDefaultPgType(HoursObject(nil), "jsonb")
+}
+
+// Value implements driver.Valuer. pgx skips the codecs of the nil slices and calls
+// Value instead, so the nil table returns the not nil all active table
+// which is encoded by the codec of the column type.
+func (h Hours) Value() (driver.Value, error) {
+	if h == nil {
+		return allActiveHours, nil
+	}
+	return hourstable.Hours(h).String(), nil
+}
+
+// BitsValue implements pgtype.BitsValuer
+func (h Hours) BitsValue() (pgtype.Bits, error) {
+	bits := pgtype.Bits{Bytes: make([]byte, BitsLen/8), Len: BitsLen, Valid: true}
+	hours := hourstable.Hours(h)
+	for i := 0; i < BitsLen; i++ {
+		if hours.TestHour(time.Weekday(i/24), byte(i%24)) {
+			bits.Bytes[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return bits, nil
+}
+
+// ScanBits implements pgtype.BitsScanner.
+// The hours after the end of the shorter bit string are inactive.
+func (h *Hours) ScanBits(v pgtype.Bits) error {
+	if !v.Valid {
+		*h = nil
+		return nil
+	}
+	if v.Len > BitsLen || int(v.Len) > len(v.Bytes)*8 {
+		return fmt.Errorf("[hourspgx] invalid bit string length %d, expected %d", v.Len, BitsLen)
+	}
+	hours := make(hourstable.Hours, 24)
+	for i := 0; i < int(v.Len); i++ {
+		if v.Bytes[i/8]&(0x80>>(i%8)) != 0 {
+			hours.SetHour(time.Weekday(i/24), byte(i%24), true)
+		}
+	}
+	if hours.IsAllActive() {
+		hours = nil
+	}
+	*h = Hours(hours)
+	return nil
+}
+
+// BytesValue implements pgtype.BytesValuer
+func (h Hours) BytesValue() ([]byte, error) {
+	return hourstable.Hours(h).MarshalBinary()
+}
+
+// ScanBytes implements pgtype.BytesScanner.
+// The text format stored in bytea is accepted as well.
+func (h *Hours) ScanBytes(v []byte) error {
+	if v == nil {
+		*h = nil
+		return nil
+	}
+	var hours hourstable.BinaryHours
+	if err := hours.Scan(v); err != nil {
+		return err
+	}
+	*h = Hours(hours)
+	return nil
+}
+
+// TextValue implements pgtype.TextValuer.
+// The text format of pgx is used for bit strings too, so the nil table
+// is written as the full table and not as "*".
+func (h Hours) TextValue() (pgtype.Text, error) {
+	if h == nil {
+		h = allActiveHours
+	}
+	return pgtype.Text{String: hourstable.Hours(h).String(), Valid: true}, nil
+}
+
+// ScanText implements pgtype.TextScanner
+func (h *Hours) ScanText(v pgtype.Text) error {
+	if !v.Valid {
+		*h = nil
+		return nil
+	}
+	hours, err := hourstable.HoursByString(v.String)
+	if err != nil {
+		return err
+	}
+	*h = Hours(hours)
+	return nil
+}
+
+// Value implements driver.Valuer, the nil table is encoded as all active hours, not NULL
+func (h HoursObject) Value() (driver.Value, error) {
+	return h.MarshalJSON()
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (h HoursObject) MarshalJSON() ([]byte, error) {
+	return hourstable.HoursObject(h).MarshalJSON()
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (h *HoursObject) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*h = nil
+		return nil
+	}
+	return (*hourstable.HoursObject)(h).UnmarshalJSON(data)
+}
+
+var (
+	_ driver.Valuer       = Hours(nil)
+	_ driver.Valuer       = HoursObject(nil)
+	_ pgtype.BitsValuer   = Hours(nil)
+	_ pgtype.BitsScanner  = (*Hours)(nil)
+	_ pgtype.BytesValuer  = Hours(nil)
+	_ pgtype.BytesScanner = (*Hours)(nil)
+	_ pgtype.TextValuer   = Hours(nil)
+	_ pgtype.TextScanner  = (*Hours)(nil)
+)
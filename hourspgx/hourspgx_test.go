@@ -0,0 +1,190 @@
+package hourspgx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/geniusrabbit/hourstable"
+	"github.com/geniusrabbit/hourstable/internal/hourstest"
+)
+
+func TestHours_Codecs(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	codecs := []struct {
+		name   string
+		oid    uint32
+		format int16
+	}{
+		{name: "bit text", oid: pgtype.BitOID, format: pgtype.TextFormatCode},
+		{name: "bit binary", oid: pgtype.BitOID, format: pgtype.BinaryFormatCode},
+		{name: "varbit text", oid: pgtype.VarbitOID, format: pgtype.TextFormatCode},
+		{name: "varbit binary", oid: pgtype.VarbitOID, format: pgtype.BinaryFormatCode},
+		{name: "bytea text", oid: pgtype.ByteaOID, format: pgtype.TextFormatCode},
+		{name: "bytea binary", oid: pgtype.ByteaOID, format: pgtype.BinaryFormatCode},
+		{name: "text text", oid: pgtype.TextOID, format: pgtype.TextFormatCode},
+		{name: "text binary", oid: pgtype.TextOID, format: pgtype.BinaryFormatCode},
+	}
+
+	for _, tt := range hourstest.Tables() {
+		for _, codec := range codecs {
+			t.Run(tt.Name+"/"+codec.name, func(t *testing.T) {
+				data, err := m.Encode(codec.oid, codec.format, Hours(tt.Hours), nil)
+				if err != nil || data == nil {
+					t.Fatalf("Encode() = %v, %v, expected not NULL", data, err)
+				}
+				var decoded Hours
+				if err = m.Scan(codec.oid, codec.format, data, &decoded); err != nil {
+					t.Fatalf("Scan(%q) error = %v", data, err)
+				}
+				if h := hourstable.Hours(decoded); !h.Equal(tt.Hours) {
+					t.Errorf("Scan() = %v, expected %v", h, tt.Hours)
+				}
+			})
+		}
+	}
+
+	// NULL is the missing table of every codec
+	for _, codec := range codecs {
+		decoded := Hours(make(hourstable.Hours, 24))
+		if err := m.Scan(codec.oid, codec.format, nil, &decoded); err != nil || decoded != nil {
+			t.Errorf("Scan(%s, NULL) = %v, %v, expected nil", codec.name, decoded, err)
+		}
+	}
+}
+
+func TestHours_WireFixtures(t *testing.T) {
+	m := pgtype.NewMap()
+	business := hourstable.MustHoursByRanges("Mon-Fri 9-17")
+
+	// The bit string in text form is the same as the text format of the hours
+	data, err := m.Encode(pgtype.BitOID, pgtype.TextFormatCode, Hours(business), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != business.String() {
+		t.Errorf("Encode(bit, text) = %s, expected %s", data, business.String())
+	}
+
+	// Binary bit string is the length of bits and the bits from the highest one
+	if data, err = m.Encode(pgtype.BitOID, pgtype.BinaryFormatCode, Hours(business), nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := binary.BigEndian.Uint32(data); n != BitsLen || len(data) != 4+BitsLen/8 {
+		t.Fatalf("Encode(bit, binary) = %x, expected %d bits", data, BitsLen)
+	}
+	// The byte of Monday 8h-15h (bits 32-39) has active 9h-15h
+	if data[4+32/8] != 0x7f {
+		t.Errorf("Encode(bit, binary) byte = %#x, expected 0x7f", data[4+32/8])
+	}
+
+	// bytea keeps the compact binary format
+	if data, err = m.Encode(pgtype.ByteaOID, pgtype.BinaryFormatCode, Hours(business), nil); err != nil {
+		t.Fatal(err)
+	}
+	if expected, _ := business.MarshalBinary(); !bytes.Equal(data, expected) {
+		t.Errorf("Encode(bytea, binary) = %x, expected %x", data, expected)
+	}
+
+	// Legacy text stored in bytea and the short varbit are accepted
+	var h Hours
+	if err = m.Scan(pgtype.ByteaOID, pgtype.BinaryFormatCode, []byte(business.String()), &h); err != nil || !hourstable.Hours(h).Equal(business) {
+		t.Errorf("Scan(bytea) = %v, %v, expected %v", h, err, business)
+	}
+	if err = m.Scan(pgtype.VarbitOID, pgtype.TextFormatCode, []byte("1"), &h); err != nil || !hourstable.Hours(h).Equal(hourstable.MustHoursByRanges("Sun 0-1")) {
+		t.Errorf("Scan(varbit) = %v, %v", h, err)
+	}
+	if err = m.Scan(pgtype.VarbitOID, pgtype.TextFormatCode, []byte(strings.Repeat("0", BitsLen+1)), &h); err == nil {
+		t.Error("expected error of the long bit string")
+	}
+
+	// The garbage bits of the table are not written
+	garbage := append(hourstable.Hours{}, business...)
+	for i := range garbage {
+		garbage[i] |= 0x80
+	}
+	if data, err = m.Encode(pgtype.BitOID, pgtype.TextFormatCode, Hours(garbage), nil); err != nil || string(data) != business.String() {
+		t.Errorf("Encode(bit, text) = %s, %v, expected %s", data, err, business.String())
+	}
+}
+
+func TestHours_AllActive(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	// The nil table is all active hours, NULL & mask would be NULL in SQL
+	tests := []struct {
+		name   string
+		oid    uint32
+		format int16
+		data   []byte
+	}{
+		{name: "bit text", oid: pgtype.BitOID, format: pgtype.TextFormatCode, data: []byte(strings.Repeat("1", BitsLen))},
+		{name: "varbit binary", oid: pgtype.VarbitOID, format: pgtype.BinaryFormatCode, data: append([]byte{0, 0, 0, BitsLen}, bytes.Repeat([]byte{0xff}, BitsLen/8)...)},
+		{name: "bytea binary", oid: pgtype.ByteaOID, format: pgtype.BinaryFormatCode, data: []byte{0x10}},
+		{name: "text text", oid: pgtype.TextOID, format: pgtype.TextFormatCode, data: []byte(strings.Repeat("1", BitsLen))},
+		{name: "unknown", oid: 0, format: pgtype.TextFormatCode, data: []byte(strings.Repeat("1", BitsLen))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := m.Encode(tt.oid, tt.format, Hours(nil), nil)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if data == nil || !bytes.Equal(data, tt.data) {
+				t.Errorf("Encode() = %q, expected %q", data, tt.data)
+			}
+		})
+	}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		data, err := m.Encode(pgtype.JSONBOID, format, HoursObject(nil), nil)
+		if err != nil || data == nil {
+			t.Fatalf("Encode(jsonb) = %v, %v, expected not NULL", data, err)
+		}
+		var decoded HoursObject
+		if err = m.Scan(pgtype.JSONBOID, format, data, &decoded); err != nil || !hourstable.Hours(decoded).IsAllActive() {
+			t.Errorf("Scan(%s) = %v, %v, expected all active", data, decoded, err)
+		}
+	}
+}
+
+func TestHoursObject_JSONB(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+	business := hourstable.MustHoursByRanges("Mon-Fri 9-17")
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		data, err := m.Encode(pgtype.JSONBOID, format, HoursObject(business), nil)
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		if format == pgtype.BinaryFormatCode && data[0] != 1 {
+			t.Errorf("Encode() jsonb version = %d, expected 1", data[0])
+		}
+		var decoded HoursObject
+		if err = m.Scan(pgtype.JSONBOID, format, data, &decoded); err != nil {
+			t.Fatalf("Scan(%s) error = %v", data, err)
+		}
+		if !hourstable.Hours(decoded).Equal(business) {
+			t.Errorf("Scan() = %v, expected %v", decoded, business)
+		}
+		if err = m.Scan(pgtype.JSONBOID, format, nil, &decoded); err != nil || decoded != nil {
+			t.Errorf("Scan(NULL) = %v, %v, expected nil", decoded, err)
+		}
+	}
+
+	// The default type of unknown parameter
+	if tp, ok := m.TypeForValue(Hours(nil)); !ok || tp.Name != "varbit" {
+		t.Errorf("TypeForValue(Hours) = %v, expected varbit", tp)
+	}
+	if tp, ok := m.TypeForValue(HoursObject(nil)); !ok || tp.Name != "jsonb" {
+		t.Errorf("TypeForValue(HoursObject) = %v, expected jsonb", tp)
+	}
+}
@@ -0,0 +1,38 @@
+// Package hourstest contains the hours tables shared by the tests
+// of the hours formats in the core package and in the codec modules.
+package hourstest
+
+// Table is the named hours table in the layout of hourstable.Hours:
+// the byte of every hour keeps the bits of the week days, Sunday is the lowest bit
+type Table struct {
+	Name  string
+	Hours []byte
+}
+
+// Tables returns the new copy of the tables which every format must keep as is.
+// The nil table is all active and the table of zeros must not become all active.
+func Tables() []Table {
+	return []Table{
+		{Name: "all active", Hours: nil},
+		{Name: "no active", Hours: make([]byte, 24)},
+		{Name: "business", Hours: business()},
+		{Name: "week ends", Hours: weekEnds()},
+	}
+}
+
+// business is the "Mon-Fri 9-17" table
+func business() []byte {
+	h := make([]byte, 24)
+	for hour := 9; hour < 17; hour++ {
+		h[hour] = 0x3e
+	}
+	return h
+}
+
+// weekEnds is the "Sun 0-1; Sat 23-24" table, the first and the last hours of the week
+func weekEnds() []byte {
+	h := make([]byte, 24)
+	h[0] = 0x01
+	h[23] = 0x40
+	return h
+}
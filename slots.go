@@ -0,0 +1,394 @@
+package hourstable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Slots errors list
+var (
+	ErrInvalidSlotResolution = errors.New("[slots] invalid slot resolution")
+	ErrTooMuchSlotsForDecode = errors.New("[slots] too much slots for decode, more then for a week")
+	ErrSlotsLossyConversion  = errors.New("[slots] table can't be converted to hours without loss")
+)
+
+// SlotResolution is a duration of one slot of the table in minutes
+type SlotResolution int
+
+// Supported resolutions of the slot tables
+const (
+	Resolution60Min SlotResolution = 60
+	Resolution30Min SlotResolution = 30
+	Resolution15Min SlotResolution = 15
+	Resolution5Min  SlotResolution = 5
+
+	// DefaultSlotResolution is used for the zero value of Slots
+	DefaultSlotResolution = Resolution60Min
+)
+
+// IsValid returns true if the resolution divides an hour into the whole number of slots
+func (r SlotResolution) IsValid() bool {
+	return r >= Resolution5Min && 60%r == 0
+}
+
+// SlotsPerDay returns number of slots in one day
+func (r SlotResolution) SlotsPerDay() int {
+	return 24 * 60 / int(r)
+}
+
+// Duration of one slot
+func (r SlotResolution) Duration() time.Duration {
+	return time.Duration(r) * time.Minute
+}
+
+// String implementation of fmt.Stringer
+func (r SlotResolution) String() string {
+	return strconv.Itoa(int(r)) + "m"
+}
+
+// Slots is the weekly table with sub-hour granularity.
+// It has the same layout as Hours, every slot of the day keeps one bit
+// per time.Weekday. The zero value is the all active table with 60 minutes resolution.
+type Slots struct {
+	resolution SlotResolution
+	table      []byte
+}
+
+// NewSlots returns the table without active slots
+func NewSlots(resolution SlotResolution) (Slots, error) {
+	if !resolution.IsValid() {
+		return Slots{}, ErrInvalidSlotResolution
+	}
+	return Slots{resolution: resolution, table: make([]byte, resolution.SlotsPerDay())}, nil
+}
+
+// SlotsFromHours converts hours into the slots table of the resolution without loss
+func SlotsFromHours(h Hours, resolution SlotResolution) (Slots, error) {
+	if !resolution.IsValid() {
+		return Slots{}, ErrInvalidSlotResolution
+	}
+	if len(h) < 1 {
+		return Slots{resolution: resolution}, nil
+	}
+	var (
+		perHour = 60 / int(resolution)
+		s       = Slots{resolution: resolution, table: make([]byte, resolution.SlotsPerDay())}
+	)
+	for i := range s.table {
+		if hour := i / perHour; hour < len(h) {
+			s.table[i] = h[hour] & daysBitMask
+		}
+	}
+	return s, nil
+}
+
+// SlotsByString returns slots value or error.
+// The string has format "<resolution>m:<slots>", the resolution part
+// could be omitted for 60 minutes tables which makes it compatible with Hours strings.
+func SlotsByString(s string) (Slots, error) {
+	resolution := DefaultSlotResolution
+	if idx := strings.Index(s, "m:"); idx >= 0 {
+		res, err := strconv.Atoi(s[:idx])
+		if err != nil || !SlotResolution(res).IsValid() {
+			return Slots{}, ErrInvalidSlotResolution
+		}
+		resolution, s = SlotResolution(res), s[idx+2:]
+	}
+
+	if s == "" || s == "*" {
+		return Slots{resolution: resolution}, nil
+	}
+
+	var (
+		perDay = resolution.SlotsPerDay()
+		slots  = Slots{resolution: resolution, table: make([]byte, perDay)}
+	)
+	if len(s) == 7*perDay && strings.Count(s, "1") == len(s) {
+		return Slots{resolution: resolution}, nil
+	}
+	if len(s) > 7*perDay {
+		return Slots{}, ErrTooMuchSlotsForDecode
+	}
+	for i, v := range s {
+		if v == '1' {
+			slots.table[i%perDay] |= byte(0x01) << byte(i/perDay)
+		}
+	}
+	return slots, nil
+}
+
+// MustSlotsByString returns slots value or panic
+func MustSlotsByString(s string) Slots {
+	slots, err := SlotsByString(s)
+	if err != nil {
+		panic(err)
+	}
+	return slots
+}
+
+// Resolution of the table
+func (s Slots) Resolution() SlotResolution {
+	if s.resolution == 0 {
+		return DefaultSlotResolution
+	}
+	return s.resolution
+}
+
+// Hours converts the table into hours if every hour has the same state of all its slots
+func (s Slots) Hours() (Hours, error) {
+	if len(s.table) < 1 {
+		return nil, nil
+	}
+	var (
+		perHour = 60 / int(s.Resolution())
+		h       = make(Hours, 24)
+	)
+	for i, mask := range s.table {
+		hour := i / perHour
+		if i%perHour == 0 {
+			h[hour] = mask & daysBitMask
+		} else if h[hour] != mask&daysBitMask {
+			return nil, ErrSlotsLossyConversion
+		}
+	}
+	return h, nil
+}
+
+// String implementation of fmt.Stringer
+func (s Slots) String() string {
+	var buff bytes.Buffer
+	if s.Resolution() != DefaultSlotResolution {
+		buff.WriteString(s.Resolution().String())
+		buff.WriteByte(':')
+	}
+	if len(s.table) < 1 {
+		buff.WriteString(AllActiveHoursString)
+		return buff.String()
+	}
+	for dayOfWeek := time.Weekday(0); dayOfWeek < 7; dayOfWeek++ {
+		buff.WriteString(binaryToHours(s.table, dayOfWeek))
+	}
+	return buff.String()
+}
+
+// Value implementation of valuer for database/sql
+func (s Slots) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Scan - Implement the database/sql scanner interface
+func (s *Slots) Scan(value any) (err error) {
+	if value == nil {
+		*s = Slots{}
+		return nil
+	}
+
+	var newSlots Slots
+	switch v := value.(type) {
+	case []byte:
+		if newSlots, err = SlotsByString(string(v)); err == nil {
+			*s = newSlots
+		}
+	case string:
+		if newSlots, err = SlotsByString(v); err == nil {
+			*s = newSlots
+		}
+	default:
+		err = fmt.Errorf("[slots] unsupported decode type %T", value)
+	}
+	return
+}
+
+// Merge from another slots table, the slot becomes active
+// if any part of it is active in s2
+func (s Slots) Merge(s2 Slots) {
+	if len(s.table) < 1 {
+		return
+	}
+	if len(s2.table) < 1 {
+		for i := range s.table {
+			s.table[i] = 0xff
+		}
+		return
+	}
+	var (
+		res  = int(s.Resolution())
+		res2 = int(s2.Resolution())
+	)
+	for i, mask := range s2.table {
+		from, to := i*res2/res, ((i+1)*res2-1)/res
+		for j := from; j <= to && j < len(s.table); j++ {
+			s.table[j] |= mask
+		}
+	}
+}
+
+// IsAllActive then return the true
+func (s Slots) IsAllActive() bool {
+	if len(s.table) < 1 {
+		return true
+	}
+	for _, bt := range s.table {
+		if bt&daysBitMask != daysBitMask {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNoActive then return the true
+func (s Slots) IsNoActive() bool {
+	if len(s.table) < 1 {
+		return false
+	}
+	for _, bt := range s.table {
+		if bt&daysBitMask != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal comarison of two slot tables, the tables with different
+// resolution are equal if they describe the same time
+func (s Slots) Equal(s2 Slots) bool {
+	if b1, b2 := s.IsAllActive(), s2.IsAllActive(); b1 || b2 {
+		return b1 && b2
+	}
+	step := gcd(int(s.Resolution()), int(s2.Resolution()))
+	for minute := 0; minute < 24*60; minute += step {
+		if s.minuteMask(minute) != s2.minuteMask(minute) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestSlot of the day
+func (s Slots) TestSlot(weekDay time.Weekday, slot int) bool {
+	return len(s.table) < 1 || (slot >= 0 && len(s.table) > slot && s.table[slot]&(0x01<<byte(weekDay)) != 0)
+}
+
+// TestTime slot
+func (s Slots) TestTime(t time.Time) bool {
+	if len(s.table) < 1 {
+		return true
+	}
+	return s.TestSlot(t.Weekday(), (t.Hour()*60+t.Minute())/int(s.Resolution()))
+}
+
+// SetSlot as active or no
+func (s *Slots) SetSlot(weekDay time.Weekday, slot int, active bool) {
+	if slot < 0 || slot >= s.Resolution().SlotsPerDay() || s.TestSlot(weekDay, slot) == active {
+		return
+	}
+
+	if s.table == nil {
+		s.resolution = s.Resolution()
+		s.table = make([]byte, s.resolution.SlotsPerDay())
+		for i := range s.table {
+			s.table[i] = daysBitMask
+		}
+	}
+
+	if active {
+		s.table[slot] |= byte(0x01) << byte(weekDay)
+	} else {
+		s.table[slot] &= ^(byte(0x01) << byte(weekDay))
+	}
+}
+
+// SetHour marks all slots of the hour as active or no
+func (s *Slots) SetHour(weekDay time.Weekday, hour byte, active bool) {
+	s.SetRange(weekDay, time.Duration(hour)*time.Hour, time.Duration(hour+1)*time.Hour, active)
+}
+
+// SetRange marks all slots which start in the [from, to) interval
+// of the day as active or no. The interval is the time since midnight.
+func (s *Slots) SetRange(weekDay time.Weekday, from, to time.Duration, active bool) {
+	res := s.Resolution().Duration()
+	for slot := int((from + res - 1) / res); time.Duration(slot)*res < to; slot++ {
+		s.SetSlot(weekDay, slot, active)
+	}
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (s Slots) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (s *Slots) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	newSlots, err := SlotsByString(str)
+	if err != nil {
+		return err
+	}
+	*s = newSlots
+	return nil
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (s Slots) MarshalYAML() (any, error) {
+	return s.String(), nil
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (s *Slots) UnmarshalYAML(node *yaml.Node) error {
+	var str string
+	if err := node.Decode(&str); err != nil {
+		return err
+	}
+	newSlots, err := SlotsByString(str)
+	if err != nil {
+		return err
+	}
+	*s = newSlots
+	return nil
+}
+
+// Clone returns a copy of Slots
+func (s Slots) Clone() Slots {
+	if s.table == nil {
+		return Slots{resolution: s.resolution}
+	}
+	table := make([]byte, len(s.table))
+	copy(table, s.table)
+	return Slots{resolution: s.resolution, table: table}
+}
+
+// minuteMask returns week days mask of the slot which contains the minute of the day
+func (s Slots) minuteMask(minute int) byte {
+	if slot := minute / int(s.Resolution()); slot < len(s.table) {
+		return s.table[slot] & daysBitMask
+	}
+	return 0
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+var (
+	_ json.Marshaler   = Slots{}
+	_ json.Unmarshaler = (*Slots)(nil)
+	_ yaml.Marshaler   = Slots{}
+	_ yaml.Unmarshaler = (*Slots)(nil)
+	_ driver.Valuer    = Slots{}
+	_ sql.Scanner      = (*Slots)(nil)
+)
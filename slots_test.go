@@ -0,0 +1,254 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSlots_TestTime(t *testing.T) {
+	lunch, _ := NewSlots(Resolution15Min)
+	for day := time.Monday; day <= time.Friday; day++ {
+		lunch.SetRange(day, 11*time.Hour+30*time.Minute, 13*time.Hour+45*time.Minute, true)
+	}
+
+	tests := []struct {
+		name     string
+		slots    Slots
+		testTime time.Time
+		expected bool
+	}{
+		{
+			name:     "zero value (all active)",
+			slots:    Slots{},
+			testTime: time.Date(2026, 1, 12, 11, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "before lunch",
+			slots:    lunch,
+			testTime: time.Date(2026, 1, 12, 11, 29, 0, 0, time.UTC), // Monday
+			expected: false,
+		},
+		{
+			name:     "lunch start",
+			slots:    lunch,
+			testTime: time.Date(2026, 1, 12, 11, 30, 0, 0, time.UTC), // Monday
+			expected: true,
+		},
+		{
+			name:     "lunch last slot",
+			slots:    lunch,
+			testTime: time.Date(2026, 1, 12, 13, 44, 59, 0, time.UTC), // Monday
+			expected: true,
+		},
+		{
+			name:     "after lunch",
+			slots:    lunch,
+			testTime: time.Date(2026, 1, 12, 13, 45, 0, 0, time.UTC), // Monday
+			expected: false,
+		},
+		{
+			name:     "weekend",
+			slots:    lunch,
+			testTime: time.Date(2026, 1, 17, 12, 0, 0, 0, time.UTC), // Saturday
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.slots.TestTime(tt.testTime); got != tt.expected {
+				t.Errorf("TestTime() = %v, expected %v for time %v", got, tt.expected, tt.testTime)
+			}
+		})
+	}
+}
+
+func TestSlots_Hours(t *testing.T) {
+	business := MustHoursByString(DisabledDayHoursString + "000000000111111111000000")
+
+	for _, res := range []SlotResolution{Resolution60Min, Resolution30Min, Resolution15Min, Resolution5Min} {
+		t.Run(res.String(), func(t *testing.T) {
+			slots, err := SlotsFromHours(business, res)
+			if err != nil {
+				t.Fatalf("SlotsFromHours failed: %v", err)
+			}
+			if len(slots.String()) != len(res.String())+1+7*res.SlotsPerDay() && res != Resolution60Min {
+				t.Errorf("invalid string length of %s", slots)
+			}
+			back, err := slots.Hours()
+			if err != nil {
+				t.Fatalf("Hours failed: %v", err)
+			}
+			if !back.Equal(business) {
+				t.Errorf("Hours() = %s, expected %s", back, business)
+			}
+		})
+	}
+
+	t.Run("lossy", func(t *testing.T) {
+		slots, _ := NewSlots(Resolution30Min)
+		slots.SetSlot(time.Monday, 1, true)
+		if _, err := slots.Hours(); err != ErrSlotsLossyConversion {
+			t.Errorf("Hours() error = %v, expected %v", err, ErrSlotsLossyConversion)
+		}
+	})
+
+	t.Run("invalid resolution", func(t *testing.T) {
+		if _, err := SlotsFromHours(business, 7); err != ErrInvalidSlotResolution {
+			t.Errorf("SlotsFromHours() error = %v, expected %v", err, ErrInvalidSlotResolution)
+		}
+		if _, err := NewSlots(0); err != ErrInvalidSlotResolution {
+			t.Errorf("NewSlots() error = %v, expected %v", err, ErrInvalidSlotResolution)
+		}
+	})
+}
+
+func TestSlotsByString(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		result  string
+		wantErr bool
+	}{
+		{
+			name:   "all active",
+			input:  "*",
+			result: "*",
+		},
+		{
+			name:   "all active 30 minutes",
+			input:  "30m:*",
+			result: "30m:*",
+		},
+		{
+			name:   "hours compatible",
+			input:  ActiveDayHoursString,
+			result: ActiveDayHoursString + strings.Repeat(DisabledDayHoursString, 6),
+		},
+		{
+			name:   "full week of ones",
+			input:  "15m:" + strings.Repeat("1", 7*96),
+			result: "15m:*",
+		},
+		{
+			name:   "15 minutes",
+			input:  "15m:0011",
+			result: "15m:0011" + strings.Repeat("0", 7*96-4),
+		},
+		{
+			name:    "invalid resolution",
+			input:   "7m:0011",
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			input:   "30m:" + strings.Repeat("0", 7*48+1),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots, err := SlotsByString(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SlotsByString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && (slots.table != nil || slots.resolution != 0) {
+				t.Errorf("SlotsByString() = %s, expected zero value with error", slots)
+			}
+			if !tt.wantErr && slots.String() != tt.result {
+				t.Errorf("String() = %s, expected %s", slots, tt.result)
+			}
+		})
+	}
+}
+
+func TestSlots_MergeEqual(t *testing.T) {
+	hourly, _ := NewSlots(Resolution60Min)
+	hourly.SetHour(time.Monday, 9, true)
+
+	quarter, _ := NewSlots(Resolution15Min)
+	quarter.SetSlot(time.Monday, 10*4+2, true) // 10:30-10:45
+
+	hourly.Merge(quarter)
+	expected, _ := NewSlots(Resolution30Min)
+	expected.SetHour(time.Monday, 9, true)
+	expected.SetHour(time.Monday, 10, true)
+	if !hourly.Equal(expected) {
+		t.Errorf("Merge() = %s, expected %s", hourly, expected)
+	}
+
+	quarter.Merge(Slots{})
+	if !quarter.IsAllActive() || !quarter.Equal(Slots{}) {
+		t.Errorf("Merge() with all active should be all active: %s", quarter)
+	}
+
+	empty, _ := NewSlots(Resolution5Min)
+	if !empty.IsNoActive() || empty.Equal(expected) {
+		t.Errorf("empty table should not be equal to %s", expected)
+	}
+
+	clone := expected.Clone()
+	clone.SetHour(time.Monday, 9, false)
+	if clone.Equal(expected) {
+		t.Errorf("Clone() should not share the table")
+	}
+}
+
+func TestSlots_Marshal(t *testing.T) {
+	type item struct {
+		Slots Slots `json:"slots" yaml:"slots"`
+	}
+
+	src := item{Slots: MustSlotsByString("30m:0000000000000000000000111111")}
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var dst item
+		if err := json.Unmarshal(data, &dst); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if !dst.Slots.Equal(src.Slots) || dst.Slots.Resolution() != Resolution30Min {
+			t.Errorf("JSON round trip %s, expected %s", dst.Slots, src.Slots)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := yaml.Marshal(src)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var dst item
+		if err := yaml.Unmarshal(data, &dst); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if !dst.Slots.Equal(src.Slots) {
+			t.Errorf("YAML round trip %s, expected %s", dst.Slots, src.Slots)
+		}
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		value, err := src.Slots.Value()
+		if err != nil {
+			t.Fatalf("Value failed: %v", err)
+		}
+		var dst Slots
+		if err := dst.Scan([]byte(value.(string))); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		if !dst.Equal(src.Slots) {
+			t.Errorf("SQL round trip %s, expected %s", dst, src.Slots)
+		}
+		if err := dst.Scan(nil); err != nil || !dst.IsAllActive() {
+			t.Errorf("Scan(nil) should reset the table")
+		}
+	})
+}
@@ -0,0 +1,304 @@
+package hourstable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State hours errors list
+var (
+	ErrInvalidStatesNumber    = errors.New("[state_hours] invalid number of states, must be 2-16")
+	ErrInvalidHourState       = errors.New("[state_hours] invalid state of the hour")
+	ErrTooMuchStatesForDecode = errors.New("[state_hours] too much hours for decode, more then 24*7")
+)
+
+// Limits of the number of states
+const (
+	MinHourStates = 2
+	MaxHourStates = 16
+
+	// DefaultHourStates is used for the zero value of StateHours
+	DefaultHourStates = MinHourStates
+)
+
+const (
+	stateHoursChars = "0123456789abcdef"
+	stateHoursSize  = 7 * 24 / 2
+)
+
+// HourState is the state of the hour in the StateHours table
+type HourState byte
+
+// String implementation of fmt.Stringer
+func (s HourState) String() string {
+	if int(s) >= len(stateHoursChars) {
+		return strconv.Itoa(int(s))
+	}
+	return stateHoursChars[s : s+1]
+}
+
+// StateHours is the weekly table where every hour keeps one of the 2-16 states.
+// The states are packed into the nibbles, the hour i of the week (day*24+hour)
+// is the low nibble of the byte i/2 for even i and the high nibble for odd.
+// The zero value is the table of two states with all hours in the state 0.
+type StateHours struct {
+	states int
+	table  []byte
+}
+
+// NewStateHours returns the table with all hours in the state 0
+func NewStateHours(states int) (StateHours, error) {
+	if states < MinHourStates || states > MaxHourStates {
+		return StateHours{}, ErrInvalidStatesNumber
+	}
+	return StateHours{states: states}, nil
+}
+
+// StateHoursByString returns state hours value or error.
+// The string has format "<states>s:<hours>" with one hex digit of the state
+// per hour in the same order as Hours. The states part could be omitted
+// for two states tables which makes it compatible with the Hours strings
+// of 0/1 and "*" as all hours in the state 1.
+// The hours after the end of the string are in the state 0.
+func StateHoursByString(s string) (StateHours, error) {
+	states := DefaultHourStates
+	if idx := strings.Index(s, "s:"); idx >= 0 {
+		n, err := strconv.Atoi(s[:idx])
+		if err != nil || n < MinHourStates || n > MaxHourStates {
+			return StateHours{}, ErrInvalidStatesNumber
+		}
+		states, s = n, s[idx+2:]
+	}
+	if len(s) > 7*24 {
+		return StateHours{}, ErrTooMuchStatesForDecode
+	}
+
+	sh := StateHours{states: states}
+	if s == AllActiveHoursString && states == MinHourStates {
+		sh.SetHours(nil, 1)
+		return sh, nil
+	}
+	for i := 0; i < len(s); i++ {
+		state := strings.IndexByte(stateHoursChars, s[i])
+		if state < 0 || state >= states {
+			return StateHours{}, ErrInvalidHourState
+		}
+		sh.SetHour(time.Weekday(i/24), byte(i%24), HourState(state))
+	}
+	return sh, nil
+}
+
+// MustStateHoursByString returns state hours value or panic
+func MustStateHoursByString(s string) StateHours {
+	sh, err := StateHoursByString(s)
+	if err != nil {
+		panic(err)
+	}
+	return sh
+}
+
+// States returns number of states of the table
+func (s StateHours) States() int {
+	if s.states == 0 {
+		return DefaultHourStates
+	}
+	return s.states
+}
+
+// State of the hour
+func (s StateHours) State(weekDay time.Weekday, hour byte) HourState {
+	if len(s.table) < 1 || weekDay < 0 || weekDay > time.Saturday || hour > 23 {
+		return 0
+	}
+	i := int(weekDay)*24 + int(hour)
+	return HourState(s.table[i/2]>>(4*(i%2))) & 0x0f
+}
+
+// StateTime returns the state of the hour of the time
+func (s StateHours) StateTime(t time.Time) HourState {
+	return s.State(t.Weekday(), byte(t.Hour()))
+}
+
+// TestHour returns true if the hour is in the state
+func (s StateHours) TestHour(weekDay time.Weekday, hour byte, state HourState) bool {
+	return s.State(weekDay, hour) == state
+}
+
+// TestTime returns true if the hour of the time is in the state
+func (s StateHours) TestTime(t time.Time, state HourState) bool {
+	return s.StateTime(t) == state
+}
+
+// SetHour state, the invalid states are ignored
+func (s *StateHours) SetHour(weekDay time.Weekday, hour byte, state HourState) {
+	if int(state) >= s.States() || weekDay < 0 || weekDay > time.Saturday || hour > 23 {
+		return
+	}
+	if s.table == nil {
+		if state == 0 {
+			return
+		}
+		s.states = s.States()
+		s.table = make([]byte, stateHoursSize)
+	}
+	i := int(weekDay)*24 + int(hour)
+	shift := 4 * (i % 2)
+	s.table[i/2] = s.table[i/2]&^(0x0f<<shift) | byte(state)<<shift
+}
+
+// SetHours sets the state of every active hour of the table
+func (s *StateHours) SetHours(h Hours, state HourState) {
+	for weekDay := time.Sunday; weekDay <= time.Saturday; weekDay++ {
+		for hour := byte(0); hour < 24; hour++ {
+			if h.TestHour(weekDay, hour) {
+				s.SetHour(weekDay, hour, state)
+			}
+		}
+	}
+}
+
+// Hours returns the table of hours which are in any of the states
+func (s StateHours) Hours(states ...HourState) Hours {
+	h := make(Hours, 24)
+	for weekDay := time.Sunday; weekDay <= time.Saturday; weekDay++ {
+		for hour := byte(0); hour < 24; hour++ {
+			state := s.State(weekDay, hour)
+			for _, st := range states {
+				if st == state {
+					h.SetHour(weekDay, hour, true)
+					break
+				}
+			}
+		}
+	}
+	return h
+}
+
+// String implementation of fmt.Stringer
+func (s StateHours) String() string {
+	var buff bytes.Buffer
+	if s.States() != DefaultHourStates {
+		buff.WriteString(strconv.Itoa(s.States()))
+		buff.WriteString("s:")
+	}
+	for weekDay := time.Sunday; weekDay <= time.Saturday; weekDay++ {
+		for hour := byte(0); hour < 24; hour++ {
+			buff.WriteByte(stateHoursChars[s.State(weekDay, hour)])
+		}
+	}
+	return buff.String()
+}
+
+// Value implementation of valuer for database/sql
+func (s StateHours) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Scan - Implement the database/sql scanner interface
+func (s *StateHours) Scan(value any) (err error) {
+	if value == nil {
+		*s = StateHours{}
+		return nil
+	}
+
+	var newStates StateHours
+	switch v := value.(type) {
+	case []byte:
+		if newStates, err = StateHoursByString(string(v)); err == nil {
+			*s = newStates
+		}
+	case string:
+		if newStates, err = StateHoursByString(v); err == nil {
+			*s = newStates
+		}
+	default:
+		err = fmt.Errorf("[state_hours] unsupported decode type %T", value)
+	}
+	return
+}
+
+// Equal comarison of two state tables
+func (s StateHours) Equal(s2 StateHours) bool {
+	if s.States() != s2.States() {
+		return false
+	}
+	for i := 0; i < stateHoursSize; i++ {
+		if s.tableByte(i) != s2.tableByte(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (s StateHours) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (s *StateHours) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	newStates, err := StateHoursByString(str)
+	if err != nil {
+		return err
+	}
+	*s = newStates
+	return nil
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (s StateHours) MarshalYAML() (any, error) {
+	return s.String(), nil
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (s *StateHours) UnmarshalYAML(node *yaml.Node) error {
+	var str string
+	if err := node.Decode(&str); err != nil {
+		return err
+	}
+	newStates, err := StateHoursByString(str)
+	if err != nil {
+		return err
+	}
+	*s = newStates
+	return nil
+}
+
+// Clone returns a copy of StateHours
+func (s StateHours) Clone() StateHours {
+	if s.table == nil {
+		return StateHours{states: s.states}
+	}
+	table := make([]byte, len(s.table))
+	copy(table, s.table)
+	return StateHours{states: s.states, table: table}
+}
+
+func (s StateHours) tableByte(i int) byte {
+	if i < len(s.table) {
+		return s.table[i]
+	}
+	return 0
+}
+
+var (
+	_ json.Marshaler   = StateHours{}
+	_ json.Unmarshaler = (*StateHours)(nil)
+	_ yaml.Marshaler   = StateHours{}
+	_ yaml.Unmarshaler = (*StateHours)(nil)
+	_ driver.Valuer    = StateHours{}
+	_ sql.Scanner      = (*StateHours)(nil)
+)
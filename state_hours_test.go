@@ -0,0 +1,176 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	stateClosed HourState = iota
+	stateOpen
+	stateLimited
+	stateMaintenance
+)
+
+func testStateHours(t *testing.T) StateHours {
+	s, err := NewStateHours(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetHours(MustHoursByRanges("Mon-Fri 9-18"), stateOpen)
+	s.SetHours(MustHoursByRanges("Sat 10-14"), stateLimited)
+	s.SetHour(time.Sunday, 3, stateMaintenance)
+	return s
+}
+
+func TestStateHours_TestHour(t *testing.T) {
+	s := testStateHours(t)
+	tests := []struct {
+		name  string
+		time  time.Time
+		state HourState
+	}{
+		{name: "open", time: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), state: stateOpen},
+		{name: "closed", time: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), state: stateClosed},
+		{name: "limited", time: time.Date(2024, 1, 6, 13, 59, 0, 0, time.UTC), state: stateLimited},
+		{name: "maintenance", time: time.Date(2024, 1, 7, 3, 30, 0, 0, time.UTC), state: stateMaintenance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if state := s.StateTime(tt.time); state != tt.state {
+				t.Errorf("StateTime() = %v, expected %v", state, tt.state)
+			}
+			if !s.TestTime(tt.time, tt.state) || !s.TestHour(tt.time.Weekday(), byte(tt.time.Hour()), tt.state) {
+				t.Errorf("TestTime() = false, expected true of the state %v", tt.state)
+			}
+			if s.TestTime(tt.time, tt.state+1) {
+				t.Errorf("TestTime() = true, expected false of the state %v", tt.state+1)
+			}
+		})
+	}
+
+	// The states out of the range are ignored
+	s.SetHour(time.Monday, 9, 4)
+	s.SetHour(time.Monday, 24, stateLimited)
+	if !s.Equal(testStateHours(t)) {
+		t.Errorf("SetHour() invalid state changed the table %s", s)
+	}
+
+	var zero StateHours
+	if zero.States() != DefaultHourStates || zero.State(time.Monday, 1) != 0 {
+		t.Errorf("zero value = %s, expected two states table", zero)
+	}
+	if _, err := NewStateHours(17); err != ErrInvalidStatesNumber {
+		t.Errorf("NewStateHours(17) error = %v, expected %v", err, ErrInvalidStatesNumber)
+	}
+}
+
+func TestStateHours_Hours(t *testing.T) {
+	s := testStateHours(t)
+	tests := []struct {
+		name   string
+		states []HourState
+		hours  Hours
+	}{
+		{name: "open", states: []HourState{stateOpen}, hours: MustHoursByRanges("Mon-Fri 9-18")},
+		{name: "serving", states: []HourState{stateOpen, stateLimited}, hours: MustHoursByRanges("Mon-Fri 9-18; Sat 10-14")},
+		{name: "maintenance", states: []HourState{stateMaintenance}, hours: MustHoursByRanges("Sun 3-4")},
+		{name: "any", states: []HourState{stateClosed, stateOpen, stateLimited, stateMaintenance}, hours: nil},
+		{name: "none", states: nil, hours: make(Hours, 24)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if h := s.Hours(tt.states...); !h.Equal(tt.hours) || h.IsNoActive() != tt.hours.IsNoActive() {
+				t.Errorf("Hours() = %v, expected %v", h, tt.hours)
+			}
+		})
+	}
+}
+
+func TestStateHours_String(t *testing.T) {
+	business := MustHoursByRanges("Mon-Fri 9-17")
+	s := testStateHours(t)
+	tests := []struct {
+		name  string
+		input string
+		state StateHours
+		err   error
+	}{
+		{name: "hours", input: business.String(), state: func() (s StateHours) { s.SetHours(business, 1); return s }()},
+		{name: "all active", input: "*", state: func() (s StateHours) { s.SetHours(nil, 1); return s }()},
+		{name: "states", input: s.String(), state: s},
+		{name: "short", input: "3s:0012", state: func() StateHours {
+			s, _ := NewStateHours(3)
+			s.SetHour(time.Sunday, 2, 1)
+			s.SetHour(time.Sunday, 3, 2)
+			return s
+		}()},
+		{name: "invalid state", input: "3s:0013", err: ErrInvalidHourState},
+		{name: "invalid states number", input: "1s:0", err: ErrInvalidStatesNumber},
+		{name: "too long", input: strings.Repeat("0", 7*24+1), err: ErrTooMuchStatesForDecode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := StateHoursByString(tt.input)
+			if err != tt.err {
+				t.Fatalf("StateHoursByString() error = %v, expected %v", err, tt.err)
+			}
+			if err == nil && !state.Equal(tt.state) {
+				t.Errorf("StateHoursByString() = %s, expected %s", state, tt.state)
+			}
+		})
+	}
+
+	if str := s.String(); !strings.HasPrefix(str, "4s:000300") || len(str) != 3+7*24 {
+		t.Errorf("String() = %s", str)
+	}
+	if str := MustStateHoursByString(business.String()).String(); str != business.String() {
+		t.Errorf("String() = %s, expected %s", str, business.String())
+	}
+}
+
+func TestStateHours_Codecs(t *testing.T) {
+	type item struct {
+		States StateHours `json:"states" yaml:"states"`
+	}
+	s := testStateHours(t)
+
+	data, err := json.Marshal(item{States: s})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded item
+	if err = json.Unmarshal(data, &decoded); err != nil || !decoded.States.Equal(s) {
+		t.Errorf("json.Unmarshal(%s) = %s, %v, expected %s", data, decoded.States, err, s)
+	}
+
+	if data, err = yaml.Marshal(item{States: s}); err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	decoded = item{}
+	if err = yaml.Unmarshal(data, &decoded); err != nil || !decoded.States.Equal(s) {
+		t.Errorf("yaml.Unmarshal(%s) = %s, %v, expected %s", data, decoded.States, err, s)
+	}
+
+	value, _ := s.Value()
+	var scanned StateHours
+	if err = scanned.Scan(value); err != nil || !scanned.Equal(s) {
+		t.Errorf("Scan() = %s, %v, expected %s", scanned, err, s)
+	}
+	if err = scanned.Scan(42); err == nil {
+		t.Error("expected error of the unsupported type")
+	}
+
+	clone := s.Clone()
+	clone.SetHour(time.Monday, 9, stateClosed)
+	if !s.TestHour(time.Monday, 9, stateOpen) {
+		t.Error("Clone() must not share the table")
+	}
+}
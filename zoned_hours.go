@@ -0,0 +1,304 @@
+package hourstable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ZonedHoursSeparator divides the hours table and the time zone name
+// in the string form of ZonedHours: "<hours>@<zone>"
+const ZonedHoursSeparator = "@"
+
+// ErrUnsupportedTimeZone when the location has no portable name: time.Local
+// or the custom zone which is not an IANA zone and has no fixed offset
+var ErrUnsupportedTimeZone = errors.New("[hours] unsupported time zone, expected IANA name or fixed offset")
+
+var (
+	// zoneNames caches the portable names of the locations, see zoneString
+	zoneNames sync.Map // *time.Location -> zoneName
+	// zoneLocations caches the decoded zones, so the equal zones share one location
+	zoneLocations sync.Map // string -> *time.Location
+)
+
+type zoneName struct {
+	name string
+	err  error
+}
+
+// ZonedHours is the hours table evaluated in the specific time zone.
+//
+// The time is converted into the Location before the check, so the table
+// always describes the wall clock of that zone. During DST transitions
+// the skipped hour never matches and the repeated hour matches twice,
+// exactly as a wall clock in that zone would show it.
+// Nil Location means the location of the tested time (the behaviour of Hours).
+type ZonedHours struct {
+	Hours    Hours
+	Location *time.Location
+}
+
+// In returns the view of hours evaluated in the loc time zone
+func (h Hours) In(loc *time.Location) ZonedHours {
+	return ZonedHours{Hours: h, Location: loc}
+}
+
+// ZonedHoursByString returns zoned hours value or error.
+// The string has format "<hours>@<zone>" where zone is the IANA time zone name
+// or the fixed offset "+hh:mm", the zone part is optional.
+func ZonedHoursByString(s string) (z ZonedHours, err error) {
+	hours, zone := s, ""
+	if idx := strings.LastIndex(s, ZonedHoursSeparator); idx >= 0 {
+		hours, zone = s[:idx], s[idx+len(ZonedHoursSeparator):]
+	}
+	if z.Location, err = zoneLocation(zone); err != nil {
+		return z, err
+	}
+	z.Hours, err = HoursByString(hours)
+	return z, err
+}
+
+// MustZonedHoursByString returns zoned hours value or panic
+func MustZonedHoursByString(s string) ZonedHours {
+	z, err := ZonedHoursByString(s)
+	if err != nil {
+		panic(err)
+	}
+	return z
+}
+
+// String implementation of fmt.Stringer.
+// The location without portable name is written as is,
+// the encoders return ErrUnsupportedTimeZone for it.
+func (z ZonedHours) String() string {
+	if z.Location == nil {
+		return z.Hours.String()
+	}
+	return z.Hours.String() + ZonedHoursSeparator + z.zoneName()
+}
+
+// Value implementation of valuer for database/sql
+func (z ZonedHours) Value() (driver.Value, error) {
+	return z.encode()
+}
+
+// Scan - Implement the database/sql scanner interface
+func (z *ZonedHours) Scan(value any) (err error) {
+	if value == nil {
+		*z = ZonedHours{}
+		return nil
+	}
+
+	var newHours ZonedHours
+	switch v := value.(type) {
+	case []byte:
+		if newHours, err = ZonedHoursByString(string(v)); err == nil {
+			*z = newHours
+		}
+	case string:
+		if newHours, err = ZonedHoursByString(v); err == nil {
+			*z = newHours
+		}
+	default:
+		err = fmt.Errorf("[hours] unsupported decode type %T", value)
+	}
+	return
+}
+
+// Time returns t converted into the time zone of the hours table
+func (z ZonedHours) Time(t time.Time) time.Time {
+	if z.Location == nil {
+		return t
+	}
+	return t.In(z.Location)
+}
+
+// IsAllActive then return the true
+func (z ZonedHours) IsAllActive() bool {
+	return z.Hours.IsAllActive()
+}
+
+// IsNoActive then return the true
+func (z ZonedHours) IsNoActive() bool {
+	return z.Hours.IsNoActive()
+}
+
+// Equal comarison of two zoned hour tables
+func (z ZonedHours) Equal(z2 ZonedHours) bool {
+	if z.Location != z2.Location && z.zoneName() != z2.zoneName() {
+		return false
+	}
+	return z.Hours.Equal(z2.Hours)
+}
+
+// TestHour hour in the time zone of the table
+func (z ZonedHours) TestHour(weekDay time.Weekday, hour byte) bool {
+	return z.Hours.TestHour(weekDay, hour)
+}
+
+// TestTime hour in the time zone of the table
+func (z ZonedHours) TestTime(t time.Time) bool {
+	return z.Hours.TestTime(z.Time(t))
+}
+
+// SetHour as active or no
+func (z *ZonedHours) SetHour(weekDay time.Weekday, hour byte, active bool) {
+	z.Hours.SetHour(weekDay, hour, active)
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (z ZonedHours) MarshalJSON() ([]byte, error) {
+	s, err := z.encode()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (z *ZonedHours) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	newHours, err := ZonedHoursByString(s)
+	if err != nil {
+		return err
+	}
+	*z = newHours
+	return nil
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (z ZonedHours) MarshalYAML() (any, error) {
+	return z.encode()
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (z *ZonedHours) UnmarshalYAML(node *yaml.Node) error {
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return err
+	}
+	newHours, err := ZonedHoursByString(s)
+	if err != nil {
+		return err
+	}
+	*z = newHours
+	return nil
+}
+
+// Clone returns a copy of ZonedHours
+func (z ZonedHours) Clone() ZonedHours {
+	return ZonedHours{Hours: z.Hours.Clone(), Location: z.Location}
+}
+
+// encode returns the string form or error if the zone can't be decoded back
+func (z ZonedHours) encode() (string, error) {
+	if z.Location != nil {
+		if _, err := zoneString(z.Location); err != nil {
+			return "", err
+		}
+	}
+	return z.String(), nil
+}
+
+func (z ZonedHours) zoneName() string {
+	if z.Location == nil {
+		return ""
+	}
+	if name, err := zoneString(z.Location); err == nil {
+		return name
+	}
+	return z.Location.String()
+}
+
+// zoneString returns the IANA name of the location or "+hh:mm" of the fixed zone.
+// The name is resolved once per location, the next calls don't touch tzdata.
+func zoneString(loc *time.Location) (string, error) {
+	if v, ok := zoneNames.Load(loc); ok {
+		zone := v.(zoneName)
+		return zone.name, zone.err
+	}
+	name, err := resolveZoneString(loc)
+	zoneNames.Store(loc, zoneName{name: name, err: err})
+	return name, err
+}
+
+func resolveZoneString(loc *time.Location) (string, error) {
+	name := loc.String()
+	if loc == time.Local || name == "Local" {
+		return "", ErrUnsupportedTimeZone
+	}
+	winter, summer := zoneOffsets(loc)
+	if name != "" {
+		// The custom zone could have the name of other IANA zone
+		if iana, err := time.LoadLocation(name); err == nil {
+			if w, s := zoneOffsets(iana); w == winter && s == summer {
+				return name, nil
+			}
+		}
+	}
+	if winter != summer || winter%60 != 0 {
+		return "", ErrUnsupportedTimeZone
+	}
+	sign, offset := '+', winter/60
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/60, offset%60), nil
+}
+
+func zoneOffsets(loc *time.Location) (winter, summer int) {
+	_, winter = time.Date(2000, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, summer = time.Date(2000, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	return winter, summer
+}
+
+// zoneLocation returns the cached location of the zone, nil for the empty zone
+func zoneLocation(zone string) (*time.Location, error) {
+	if zone == "" {
+		return nil, nil
+	}
+	if v, ok := zoneLocations.Load(zone); ok {
+		return v.(*time.Location), nil
+	}
+	loc, err := loadZone(zone)
+	if err != nil {
+		return nil, fmt.Errorf("[hours] invalid time zone %q: %w", zone, err)
+	}
+	v, _ := zoneLocations.LoadOrStore(zone, loc)
+	return v.(*time.Location), nil
+}
+
+// loadZone by the IANA name or the fixed offset "+hh:mm"
+func loadZone(zone string) (*time.Location, error) {
+	if zone == "Local" {
+		return nil, ErrUnsupportedTimeZone
+	}
+	if zone[0] != '+' && zone[0] != '-' {
+		return time.LoadLocation(zone)
+	}
+	t, err := time.Parse("-07:00", zone)
+	if err != nil {
+		return nil, err
+	}
+	_, offset := t.Zone()
+	return time.FixedZone(zone, offset), nil
+}
+
+var (
+	_ json.Marshaler   = ZonedHours{}
+	_ json.Unmarshaler = (*ZonedHours)(nil)
+	_ yaml.Marshaler   = ZonedHours{}
+	_ yaml.Unmarshaler = (*ZonedHours)(nil)
+	_ driver.Valuer    = ZonedHours{}
+	_ sql.Scanner      = (*ZonedHours)(nil)
+)
@@ -0,0 +1,180 @@
+package hourstable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// zonedTimetableJSON is the per day timetable with the time zone name
+type zonedTimetableJSON struct {
+	timetableJSON `yaml:",inline"`
+	Zone          string `json:"tz,omitempty" yaml:"tz,omitempty"`
+}
+
+// ZonedHoursObject is the zoned hours table stored in the JSON format of HoursObject
+// with the time zone in the "tz" key: {"mon":"*","tz":"Europe/Berlin"}
+type ZonedHoursObject ZonedHours
+
+// In returns the view of hours evaluated in the loc time zone
+func (h HoursObject) In(loc *time.Location) ZonedHoursObject {
+	return ZonedHoursObject{Hours: Hours(h), Location: loc}
+}
+
+// ZonedHoursByJSON decodes JSON format of timetable with the time zone
+func ZonedHoursByJSON(data []byte) (ZonedHours, error) {
+	var timetable zonedTimetableJSON
+	if err := json.Unmarshal(data, &timetable); err != nil {
+		return ZonedHours{}, err
+	}
+	return timetable.ToZonedHours()
+}
+
+func (tt *zonedTimetableJSON) ToZonedHours() (z ZonedHours, err error) {
+	if z.Location, err = zoneLocation(tt.Zone); err != nil {
+		return z, err
+	}
+	z.Hours = tt.timetableJSON.ToHours()
+	return z, nil
+}
+
+// String implementation of fmt.Stringer
+func (z ZonedHoursObject) String() string {
+	data, _ := json.Marshal(z.timetable())
+	return string(data)
+}
+
+// Value implementation of valuer for database/sql
+func (z ZonedHoursObject) Value() (driver.Value, error) {
+	return z.MarshalJSON()
+}
+
+// Scan - Implement the database/sql scanner interface
+func (z *ZonedHoursObject) Scan(value any) (err error) {
+	if value == nil {
+		*z = ZonedHoursObject{}
+		return nil
+	}
+
+	var newHours ZonedHours
+	switch v := value.(type) {
+	case []byte:
+		if newHours, err = ZonedHoursByJSON(v); err == nil {
+			*z = ZonedHoursObject(newHours)
+		}
+	case string:
+		if newHours, err = ZonedHoursByJSON([]byte(v)); err == nil {
+			*z = ZonedHoursObject(newHours)
+		}
+	default:
+		err = fmt.Errorf("[hours_json] unsupported decode type %T", value)
+	}
+	return
+}
+
+// Time returns t converted into the time zone of the hours table
+func (z ZonedHoursObject) Time(t time.Time) time.Time {
+	return ZonedHours(z).Time(t)
+}
+
+// IsAllActive then return the true
+func (z ZonedHoursObject) IsAllActive() bool {
+	return z.Hours.IsAllActive()
+}
+
+// IsNoActive then return the true
+func (z ZonedHoursObject) IsNoActive() bool {
+	return z.Hours.IsNoActive()
+}
+
+// Equal comarison of two zoned hour tables
+func (z ZonedHoursObject) Equal(z2 ZonedHours) bool {
+	return ZonedHours(z).Equal(z2)
+}
+
+// TestHour hour in the time zone of the table
+func (z ZonedHoursObject) TestHour(weekDay time.Weekday, hour byte) bool {
+	return z.Hours.TestHour(weekDay, hour)
+}
+
+// TestTime hour in the time zone of the table
+func (z ZonedHoursObject) TestTime(t time.Time) bool {
+	return ZonedHours(z).TestTime(t)
+}
+
+// SetHour as active or no
+func (z *ZonedHoursObject) SetHour(weekDay time.Weekday, hour byte, active bool) {
+	z.Hours.SetHour(weekDay, hour, active)
+}
+
+// MarshalJSON implements the functionality of json.Marshaler interface
+func (z ZonedHoursObject) MarshalJSON() ([]byte, error) {
+	timetable, err := z.encode()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(timetable)
+}
+
+// UnmarshalJSON implements the functionality of json.Unmarshaler interface
+func (z *ZonedHoursObject) UnmarshalJSON(data []byte) error {
+	newHours, err := ZonedHoursByJSON(data)
+	if err != nil {
+		return err
+	}
+	*z = ZonedHoursObject(newHours)
+	return nil
+}
+
+// MarshalYAML implements the functionality of yaml.Marshaler interface
+func (z ZonedHoursObject) MarshalYAML() (any, error) {
+	return z.encode()
+}
+
+// UnmarshalYAML implements the functionality of yaml.Unmarshaler interface
+func (z *ZonedHoursObject) UnmarshalYAML(node *yaml.Node) error {
+	var timetable zonedTimetableJSON
+	if err := node.Decode(&timetable); err != nil {
+		return err
+	}
+	newHours, err := timetable.ToZonedHours()
+	if err != nil {
+		return err
+	}
+	*z = ZonedHoursObject(newHours)
+	return nil
+}
+
+// Clone returns a copy of ZonedHoursObject
+func (z ZonedHoursObject) Clone() ZonedHoursObject {
+	return ZonedHoursObject(ZonedHours(z).Clone())
+}
+
+// encode returns the timetable or error if the zone can't be decoded back
+func (z ZonedHoursObject) encode() (*zonedTimetableJSON, error) {
+	if z.Location != nil {
+		if _, err := zoneString(z.Location); err != nil {
+			return nil, err
+		}
+	}
+	return z.timetable(), nil
+}
+
+func (z ZonedHoursObject) timetable() *zonedTimetableJSON {
+	timetable := &zonedTimetableJSON{Zone: ZonedHours(z).zoneName()}
+	timetable.FromHours(z.Hours)
+	return timetable
+}
+
+var (
+	_ json.Marshaler   = ZonedHoursObject{}
+	_ json.Unmarshaler = (*ZonedHoursObject)(nil)
+	_ yaml.Marshaler   = ZonedHoursObject{}
+	_ yaml.Unmarshaler = (*ZonedHoursObject)(nil)
+	_ driver.Valuer    = ZonedHoursObject{}
+	_ sql.Scanner      = (*ZonedHoursObject)(nil)
+)
@@ -0,0 +1,78 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestZonedHoursObject_Codecs(t *testing.T) {
+	type item struct {
+		Hours ZonedHoursObject `json:"hours" yaml:"hours"`
+	}
+	var (
+		berlin   = mustLoadLocation(t, "Europe/Berlin")
+		business = HoursObject(MustHoursByRanges("Mon-Fri 9-17"))
+	)
+
+	tests := []struct {
+		name  string
+		hours ZonedHoursObject
+		json  string
+	}{
+		{name: "zone", hours: business.In(berlin), json: `{"mon":"000000000111111110000000","tue":"000000000111111110000000","wed":"000000000111111110000000","thu":"000000000111111110000000","fri":"000000000111111110000000","tz":"Europe/Berlin"}`},
+		{name: "fixed zone", hours: HoursObject(nil).In(time.FixedZone("", -3*60*60)), json: `{"mon":"*","tue":"*","wed":"*","thu":"*","fri":"*","sat":"*","sun":"*","tz":"-03:00"}`},
+		{name: "no zone", hours: business.In(nil), json: business.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.hours)
+			if err != nil || string(data) != tt.json {
+				t.Fatalf("json.Marshal() = %s, %v, expected %s", data, err, tt.json)
+			}
+			var decoded ZonedHoursObject
+			if err = json.Unmarshal(data, &decoded); err != nil || !decoded.Equal(ZonedHours(tt.hours)) {
+				t.Errorf("json.Unmarshal() = %s, %v, expected %s", decoded, err, tt.hours)
+			}
+
+			if data, err = yaml.Marshal(item{Hours: tt.hours}); err != nil {
+				t.Fatalf("yaml.Marshal() error = %v", err)
+			}
+			var it item
+			if err = yaml.Unmarshal(data, &it); err != nil || !it.Hours.Equal(ZonedHours(tt.hours)) {
+				t.Errorf("yaml.Unmarshal(%s) = %s, %v, expected %s", data, it.Hours, err, tt.hours)
+			}
+
+			value, _ := tt.hours.Value()
+			var scanned ZonedHoursObject
+			if err = scanned.Scan(value); err != nil || !scanned.Equal(ZonedHours(tt.hours)) {
+				t.Errorf("Scan() = %s, %v, expected %s", scanned, err, tt.hours)
+			}
+		})
+	}
+
+	// Monday 08:30 UTC is 09:30 in Berlin
+	if now := time.Date(2026, 1, 12, 8, 30, 0, 0, time.UTC); !business.In(berlin).TestTime(now) || business.In(nil).TestTime(now) {
+		t.Error("TestTime() must check the time in the zone of the table")
+	}
+}
+
+func TestZonedHoursObject_Errors(t *testing.T) {
+	if _, err := ZonedHoursByJSON([]byte(`{"mon":"*","tz":"Mars/Olympus"}`)); err == nil {
+		t.Error("ZonedHoursByJSON() expected error of the unknown zone")
+	}
+	if _, err := json.Marshal(HoursObject(nil).In(time.Local)); !errors.Is(err, ErrUnsupportedTimeZone) {
+		t.Errorf("json.Marshal() error = %v, expected %v", err, ErrUnsupportedTimeZone)
+	}
+	var z ZonedHoursObject
+	if err := yaml.Unmarshal([]byte("mon: '*'\ntz: Local\n"), &z); !errors.Is(err, ErrUnsupportedTimeZone) {
+		t.Errorf("yaml.Unmarshal() error = %v, expected %v", err, ErrUnsupportedTimeZone)
+	}
+	if err := z.Scan(42); err == nil {
+		t.Error("expected error of the unsupported type")
+	}
+}
@@ -0,0 +1,278 @@
+package hourstable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func mustLoadLocation(t testing.TB, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s is not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestZonedHours_TestTime(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	// Active only at 02:00-03:00 on Sunday (wall clock of Berlin)
+	night := make(Hours, 24)
+	night.SetHour(time.Sunday, 2, true)
+
+	// Monday-Friday 9AM-5PM (wall clock of Berlin)
+	business := make(Hours, 24)
+	for day := time.Monday; day <= time.Friday; day++ {
+		for hour := 9; hour < 17; hour++ {
+			business.SetHour(day, byte(hour), true)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		hours    ZonedHours
+		testTime time.Time
+		expected bool
+	}{
+		{
+			name:     "nil location uses time location",
+			hours:    business.In(nil),
+			testTime: time.Date(2026, 1, 12, 8, 30, 0, 0, time.UTC), // Monday 08:30 UTC
+			expected: false,
+		},
+		{
+			name:     "winter time UTC+1",
+			hours:    business.In(berlin),
+			testTime: time.Date(2026, 1, 12, 8, 30, 0, 0, time.UTC), // Monday 09:30 CET
+			expected: true,
+		},
+		{
+			name:     "summer time UTC+2",
+			hours:    business.In(berlin),
+			testTime: time.Date(2026, 7, 13, 15, 30, 0, 0, time.UTC), // Monday 17:30 CEST
+			expected: false,
+		},
+		{
+			name:     "spring forward skips 02:00",
+			hours:    night.In(berlin),
+			testTime: time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC), // Sunday 03:30 CEST
+			expected: false,
+		},
+		{
+			name:     "fall back first 02:00",
+			hours:    night.In(berlin),
+			testTime: time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC), // Sunday 02:30 CEST
+			expected: true,
+		},
+		{
+			name:     "fall back repeated 02:00",
+			hours:    night.In(berlin),
+			testTime: time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC), // Sunday 02:30 CET
+			expected: true,
+		},
+		{
+			name:     "after fall back",
+			hours:    night.In(berlin),
+			testTime: time.Date(2026, 10, 25, 2, 30, 0, 0, time.UTC), // Sunday 03:30 CET
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hours.TestTime(tt.testTime); got != tt.expected {
+				t.Errorf("TestTime() = %v, expected %v for time %v", got, tt.expected, tt.testTime)
+			}
+		})
+	}
+}
+
+func TestZonedHoursByString(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ZonedHours
+		result   string
+		wantErr  bool
+	}{
+		{
+			name:     "all active without zone",
+			input:    "*",
+			expected: ZonedHours{},
+			result:   "*",
+		},
+		{
+			name:     "all active with zone",
+			input:    "*@Europe/Berlin",
+			expected: ZonedHours{Location: berlin},
+			result:   "*@Europe/Berlin",
+		},
+		{
+			name:     "hours with zone",
+			input:    ActiveDayHoursString + "@Europe/Berlin",
+			expected: MustHoursByString(ActiveDayHoursString).In(berlin),
+			result:   MustHoursByString(ActiveDayHoursString).String() + "@Europe/Berlin",
+		},
+		{
+			name:    "unknown zone",
+			input:   "*@Mars/Olympus",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z, err := ZonedHoursByString(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ZonedHoursByString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !z.Equal(tt.expected) {
+				t.Errorf("ZonedHoursByString() = %s, expected %s", z, tt.expected)
+			}
+			if z.String() != tt.result {
+				t.Errorf("String() = %s, expected %s", z, tt.result)
+			}
+		})
+	}
+}
+
+func TestZonedHours_Marshal(t *testing.T) {
+	type item struct {
+		Hours ZonedHours `json:"hours" yaml:"hours"`
+	}
+
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	src := item{Hours: MustHoursByString("000000000111111111000000").In(berlin)}
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var dst item
+		if err := json.Unmarshal(data, &dst); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if !dst.Hours.Equal(src.Hours) {
+			t.Errorf("JSON round trip %s, expected %s", dst.Hours, src.Hours)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := yaml.Marshal(src)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var dst item
+		if err := yaml.Unmarshal(data, &dst); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if !dst.Hours.Equal(src.Hours) {
+			t.Errorf("YAML round trip %s, expected %s", dst.Hours, src.Hours)
+		}
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		value, err := src.Hours.Value()
+		if err != nil {
+			t.Fatalf("Value failed: %v", err)
+		}
+		var dst ZonedHours
+		if err := dst.Scan([]byte(value.(string))); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		if !dst.Equal(src.Hours) {
+			t.Errorf("SQL round trip %s, expected %s", dst, src.Hours)
+		}
+		if err := dst.Scan(10); err == nil {
+			t.Errorf("Scan of unsupported type should fail")
+		}
+	})
+}
+
+func TestZonedHours_FixedZone(t *testing.T) {
+	hours := MustHoursByString("000000000111111111000000")
+	tests := []struct {
+		name string
+		loc  *time.Location
+		zone string
+	}{
+		{name: "east", loc: time.FixedZone("UTC+3", 3*60*60), zone: "+03:00"},
+		{name: "west", loc: time.FixedZone("", -(5*60*60 + 30*60)), zone: "-05:30"},
+		{name: "utc", loc: time.UTC, zone: "UTC"},
+		{name: "iana name", loc: time.FixedZone("UTC", 3*60*60), zone: "+03:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := hours.In(tt.loc)
+			data, err := json.Marshal(src)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if expected := `"` + hours.String() + "@" + tt.zone + `"`; string(data) != expected {
+				t.Errorf("Marshal() = %s, expected %s", data, expected)
+			}
+			var dst ZonedHours
+			if err := json.Unmarshal(data, &dst); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !dst.Equal(src) {
+				t.Errorf("JSON round trip %s, expected %s", dst, src)
+			}
+			now := time.Now()
+			_, offset := now.In(dst.Location).Zone()
+			if _, expected := now.In(tt.loc).Zone(); offset != expected {
+				t.Errorf("Unmarshal() offset = %d, expected %d", offset, expected)
+			}
+		})
+	}
+}
+
+func TestZonedHours_UnsupportedZone(t *testing.T) {
+	z := MustHoursByString("000000000111111111000000").In(time.Local)
+	if _, err := json.Marshal(z); !errors.Is(err, ErrUnsupportedTimeZone) {
+		t.Errorf("json.Marshal() error = %v, expected %v", err, ErrUnsupportedTimeZone)
+	}
+	if _, err := yaml.Marshal(z); err == nil {
+		t.Error("yaml.Marshal() expected error of the local time zone")
+	}
+	if _, err := z.Value(); !errors.Is(err, ErrUnsupportedTimeZone) {
+		t.Errorf("Value() error = %v, expected %v", err, ErrUnsupportedTimeZone)
+	}
+	if _, err := ZonedHoursByString("*@Local"); !errors.Is(err, ErrUnsupportedTimeZone) {
+		t.Errorf("ZonedHoursByString() error = %v, expected %v", err, ErrUnsupportedTimeZone)
+	}
+}
+
+func TestZonedHours_ZoneCache(t *testing.T) {
+	var (
+		berlin = mustLoadLocation(t, "Europe/Berlin")
+		hours  = MustHoursByString("000000000111111111000000")
+		src    = hours.In(berlin)
+		dst    = MustZonedHoursByString(src.String())
+		fixed  = hours.In(time.FixedZone("", 3*60*60))
+	)
+	if dst.Location == berlin || !dst.Equal(src) {
+		t.Fatalf("ZonedHoursByString() = %s, expected %s of other location", dst, src)
+	}
+	if z := MustZonedHoursByString(src.String()); z.Location != dst.Location {
+		t.Error("ZonedHoursByString() must share the location of the same zone")
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = src.Equal(dst)
+		_ = src.Equal(fixed)
+	})
+	if allocs != 0 {
+		t.Errorf("Equal() allocs = %v, expected 0", allocs)
+	}
+}